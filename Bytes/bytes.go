@@ -0,0 +1,495 @@
+// package Bytes provides a custom Bytes type with chainable methods
+// with API similar to that of the standard library's bytes package.
+package Bytes
+
+import (
+	"bytes"
+	"iter"
+	"unicode"
+	"unsafe"
+
+	"github.com/harishtpj/klassy/Slice"
+	"github.com/harishtpj/klassy/String"
+)
+
+// type Bytes is a view of a byte slice. Unlike a []byte it is comparable,
+// so it can be stored in a [Slice.Slice]; == reports whether two values view
+// the same bytes of memory with the same length, use [Bytes.Equal] to
+// compare their contents. The same goes for the Slice methods built on ==,
+// such as Contains, Index and Equal: use [SliceContains], [SliceIndex] and
+// [SlicesEqual] to search the results of the split functions by content.
+// Methods never modify the bytes they view.
+type Bytes struct {
+	ptr *byte
+	len int
+	cap int
+}
+
+// New return a new instance of the Bytes type viewing b without copying it
+func New(b []byte) Bytes {
+	return Bytes{ptr: unsafe.SliceData(b), len: len(b), cap: cap(b)}
+}
+
+// FromString returns a Bytes holding a copy of the bytes of s
+func FromString(s String.String) Bytes {
+	return New([]byte(s.Value()))
+}
+
+// UnsafeFromString returns a Bytes viewing the bytes of s without copying
+// them. The bytes must not be modified, as Go strings are immutable.
+func UnsafeFromString(s String.String) Bytes {
+	return New(unsafe.Slice(unsafe.StringData(s.Value()), s.Length()))
+}
+
+// Value return the underlying byte slice
+func (self Bytes) Value() []byte {
+	if self.ptr == nil {
+		return nil
+	}
+	return unsafe.Slice(self.ptr, self.cap)[:self.len:self.cap]
+}
+
+// String returns the contents of self as a string, so Bytes prints as text
+func (self Bytes) String() string {
+	return string(self.Value())
+}
+
+// ToString returns a String holding a copy of the contents of self
+func (self Bytes) ToString() String.String {
+	return String.New(string(self.Value()))
+}
+
+// UnsafeToString returns a String sharing the memory of self without
+// copying it. The bytes viewed by self must not be modified afterwards.
+func (self Bytes) UnsafeToString() String.String {
+	return String.New(unsafe.String(self.ptr, self.len))
+}
+
+// Length return the length of underlying byte slice
+func (self Bytes) Length() int {
+	return self.len
+}
+
+// Clone returns a Bytes viewing a copy of the contents of self
+func (self Bytes) Clone() Bytes {
+	return New(bytes.Clone(self.Value()))
+}
+
+// Compare returns an integer comparing self and other lexicographically.
+// The result will be 0 if their contents are equal, -1 if self < other, and +1 if self > other.
+func (self Bytes) Compare(other Bytes) int {
+	return bytes.Compare(self.Value(), other.Value())
+}
+
+// Equal reports whether self and other contain the same bytes
+func (self Bytes) Equal(other Bytes) bool {
+	return bytes.Equal(self.Value(), other.Value())
+}
+
+// SliceContains reports whether s holds an element with the same contents
+// as b. Unlike s.Contains(b), it does not require b to view the same memory.
+func SliceContains(s Slice.Slice[Bytes], b Bytes) bool {
+	return SliceIndex(s, b) >= 0
+}
+
+// SliceIndex returns the index of the first element of s with the same
+// contents as b, or -1 if there is none
+func SliceIndex(s Slice.Slice[Bytes], b Bytes) int {
+	return s.IndexFunc(b.Equal)
+}
+
+// SlicesEqual reports whether a and b have the same length and elements
+// with the same contents at each index
+func SlicesEqual(a, b Slice.Slice[Bytes]) bool {
+	if a.Length() != b.Length() {
+		return false
+	}
+	for i, item := range a.Items {
+		if !item.Equal(b.Items[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// wrapAll converts the result of a bytes split function to a Slice of Bytes
+func wrapAll(parts [][]byte) Slice.Slice[Bytes] {
+	items := make([]Bytes, len(parts))
+	for i, part := range parts {
+		items[i] = New(part)
+	}
+	return Slice.Slice[Bytes]{Items: items}
+}
+
+// wrapSeq converts an iterator of the bytes package to an iterator of Bytes
+func wrapSeq(seq iter.Seq[[]byte]) iter.Seq[Bytes] {
+	return func(yield func(Bytes) bool) {
+		for b := range seq {
+			if !yield(New(b)) {
+				return
+			}
+		}
+	}
+}
+
+// Contains reports whether subslice is within self
+func (self Bytes) Contains(subslice []byte) bool {
+	return bytes.Contains(self.Value(), subslice)
+}
+
+// ContainsAny reports whether any of the UTF-8-encoded characters in chars
+// are within self
+func (self Bytes) ContainsAny(chars string) bool {
+	return bytes.ContainsAny(self.Value(), chars)
+}
+
+// ContainsFunc reports if any UTF-8-encoded character c in self satisfy f(c)
+func (self Bytes) ContainsFunc(f func(rune) bool) bool {
+	return bytes.ContainsFunc(self.Value(), f)
+}
+
+// ContainsRune reports whether the character r is within self
+func (self Bytes) ContainsRune(r rune) bool {
+	return bytes.ContainsRune(self.Value(), r)
+}
+
+// Count counts the number of non-overlapping instances of sep in self.
+// If sep is empty, Count returns 1 + the number of UTF-8-encoded characters in self.
+func (self Bytes) Count(sep []byte) int {
+	return bytes.Count(self.Value(), sep)
+}
+
+// Cut slices self around the first instance of sep, returning the text before and after sep.
+// The found result reports whether sep appears in self. If sep does not appear in self,
+// Cut returns self, an empty Bytes, false.
+func (self Bytes) Cut(sep []byte) (before, after Bytes, found bool) {
+	b, a, f := bytes.Cut(self.Value(), sep)
+	return New(b), New(a), f
+}
+
+// CutPrefix returns self without the provided leading prefix and reports
+// whether it found the prefix. If self doesn't start with prefix, CutPrefix
+// returns self, false. If prefix is empty, CutPrefix returns self, true.
+func (self Bytes) CutPrefix(prefix []byte) (after Bytes, found bool) {
+	a, f := bytes.CutPrefix(self.Value(), prefix)
+	return New(a), f
+}
+
+// CutSuffix returns self without the provided ending suffix and reports
+// whether it found the suffix. If self doesn't end with suffix, CutSuffix
+// returns self, false. If suffix is empty, CutSuffix returns self, true.
+func (self Bytes) CutSuffix(suffix []byte) (before Bytes, found bool) {
+	b, f := bytes.CutSuffix(self.Value(), suffix)
+	return New(b), f
+}
+
+// EqualFold reports whether self and t, interpreted as UTF-8 strings, are equal
+// under simple Unicode case-folding, which is a more general form of case-insensitivity.
+func (self Bytes) EqualFold(t []byte) bool {
+	return bytes.EqualFold(self.Value(), t)
+}
+
+// Fields splits self around each instance of one or more consecutive white
+// space characters, as defined by unicode.IsSpace, returning a Slice of
+// subslices of self or an empty Slice if self contains only white space.
+func (self Bytes) Fields() Slice.Slice[Bytes] {
+	return wrapAll(bytes.Fields(self.Value()))
+}
+
+// FieldsFunc splits self at each run of characters c satisfying f(c) and
+// returns a Slice of subslices of self. If all characters in self satisfy
+// f(c), or self is empty, an empty Slice is returned.
+func (self Bytes) FieldsFunc(f func(rune) bool) Slice.Slice[Bytes] {
+	return wrapAll(bytes.FieldsFunc(self.Value(), f))
+}
+
+// FieldsFuncSeq returns an iterator over subslices of self split around runs
+// of characters satisfying f(c). The iterator yields the same subslices that
+// would be returned by self.[FieldsFunc](), but without constructing the Slice.
+func (self Bytes) FieldsFuncSeq(f func(rune) bool) iter.Seq[Bytes] {
+	return wrapSeq(bytes.FieldsFuncSeq(self.Value(), f))
+}
+
+// FieldsSeq returns an iterator over subslices of self split around runs of
+// whitespace characters, as defined by unicode.IsSpace. The iterator yields
+// the same subslices that would be returned by self.[Fields](), but without
+// constructing the Slice.
+func (self Bytes) FieldsSeq() iter.Seq[Bytes] {
+	return wrapSeq(bytes.FieldsSeq(self.Value()))
+}
+
+// HasPrefix reports if self starts with prefix
+func (self Bytes) HasPrefix(prefix []byte) bool {
+	return bytes.HasPrefix(self.Value(), prefix)
+}
+
+// HasSuffix reports if self ends with suffix
+func (self Bytes) HasSuffix(suffix []byte) bool {
+	return bytes.HasSuffix(self.Value(), suffix)
+}
+
+// Index returns the index of the first instance of sep in self,
+// or -1 if sep is not present in self.
+func (self Bytes) Index(sep []byte) int {
+	return bytes.Index(self.Value(), sep)
+}
+
+// IndexAny returns the index of the first instance of any character
+// from chars in self or -1 if no character from chars is present in self
+func (self Bytes) IndexAny(chars string) int {
+	return bytes.IndexAny(self.Value(), chars)
+}
+
+// IndexByte returns the index of the first instance of c in self,
+// or -1 if c is not present in self.
+func (self Bytes) IndexByte(c byte) int {
+	return bytes.IndexByte(self.Value(), c)
+}
+
+// IndexFunc returns the index into self of the first character
+// satisfying f(c), or -1 if none do.
+func (self Bytes) IndexFunc(f func(rune) bool) int {
+	return bytes.IndexFunc(self.Value(), f)
+}
+
+// IndexRune returns the index of the first instance of the character r,
+// or -1 if r is not present in self. If r is utf8.RuneError, it returns
+// the first instance of any invalid UTF-8 byte sequence.
+func (self Bytes) IndexRune(r rune) int {
+	return bytes.IndexRune(self.Value(), r)
+}
+
+// Join concatenates the elements of elems to create a new Bytes, with self
+// placed between the elements
+func (self Bytes) Join(elems Slice.Slice[Bytes]) Bytes {
+	parts := make([][]byte, elems.Length())
+	for i, elem := range elems.Items {
+		parts[i] = elem.Value()
+	}
+	return New(bytes.Join(parts, self.Value()))
+}
+
+// LastIndex returns the index of the last instance of sep in self,
+// or -1 if sep is not present in self.
+func (self Bytes) LastIndex(sep []byte) int {
+	return bytes.LastIndex(self.Value(), sep)
+}
+
+// LastIndexAny returns the index of the last instance of any character from
+// chars in self, or -1 if no character from chars is present in self.
+func (self Bytes) LastIndexAny(chars string) int {
+	return bytes.LastIndexAny(self.Value(), chars)
+}
+
+// LastIndexByte returns the index of the last instance of c in self,
+// or -1 if c is not present in self.
+func (self Bytes) LastIndexByte(c byte) int {
+	return bytes.LastIndexByte(self.Value(), c)
+}
+
+// LastIndexFunc returns the index into self of the last
+// character satisfying f(c), or -1 if none do.
+func (self Bytes) LastIndexFunc(f func(rune) bool) int {
+	return bytes.LastIndexFunc(self.Value(), f)
+}
+
+// Lines returns an iterator over the newline-terminated lines in self.
+// The lines yielded by the iterator include their terminating newlines.
+// If self is empty, the iterator yields no lines at all. If self does not
+// end in a newline, the final yielded line will not end in a newline.
+// It returns a single-use iterator.
+func (self Bytes) Lines() iter.Seq[Bytes] {
+	return wrapSeq(bytes.Lines(self.Value()))
+}
+
+// Map returns a copy of self with all its characters modified according to
+// the mapping function. If mapping returns a negative value, the character
+// is dropped with no replacement.
+func (self Bytes) Map(mapping func(rune) rune) Bytes {
+	return New(bytes.Map(mapping, self.Value()))
+}
+
+// Repeat returns a new Bytes consisting of count copies of self.
+//
+// It panics if count is negative or if the result of (len(self) * count) overflows.
+func (self Bytes) Repeat(count int) Bytes {
+	return New(bytes.Repeat(self.Value(), count))
+}
+
+// Replace returns a copy of self with the first n non-overlapping instances
+// of old replaced by new. If old is empty, it matches at the beginning of
+// self and after each UTF-8 sequence, yielding up to k+1 replacements for a
+// k-rune slice. If n < 0, there is no limit on the number of replacements.
+func (self Bytes) Replace(old, new []byte, n int) Bytes {
+	return New(bytes.Replace(self.Value(), old, new, n))
+}
+
+// ReplaceAll returns a copy of self with all non-overlapping instances of
+// old replaced by new.
+//
+// Equivalent to self.[Replace](old, new, -1)
+func (self Bytes) ReplaceAll(old, new []byte) Bytes {
+	return New(bytes.ReplaceAll(self.Value(), old, new))
+}
+
+// Runes interprets self as a UTF-8-encoded sequence of code points and
+// returns a slice of runes equivalent to self.
+func (self Bytes) Runes() []rune {
+	return bytes.Runes(self.Value())
+}
+
+// Split slices self into all subslices separated by sep and returns a Slice
+// of the subslices between those separators. If sep is empty, Split splits
+// after each UTF-8 sequence.
+//
+// It is equivalent to [SplitN] with a count of -1.
+//
+// To split around the first instance of a separator, see [Cut].
+func (self Bytes) Split(sep []byte) Slice.Slice[Bytes] {
+	return wrapAll(bytes.Split(self.Value(), sep))
+}
+
+// SplitAfter slices self into all subslices after each instance of sep and
+// returns a Slice of those subslices. If sep is empty, SplitAfter splits
+// after each UTF-8 sequence.
+//
+// It is equivalent to [SplitAfterN] with a count of -1.
+func (self Bytes) SplitAfter(sep []byte) Slice.Slice[Bytes] {
+	return wrapAll(bytes.SplitAfter(self.Value(), sep))
+}
+
+// SplitAfterN slices self into subslices after each instance of sep and
+// returns a Slice of those subslices.
+//
+// The count determines the number of subslices to return:
+//
+// - n > 0: at most n subslices; the last subslice will be the unsplit remainder;
+// - n == 0: the result is empty (zero subslices);
+// - n < 0: all subslices.
+func (self Bytes) SplitAfterN(sep []byte, n int) Slice.Slice[Bytes] {
+	return wrapAll(bytes.SplitAfterN(self.Value(), sep, n))
+}
+
+// SplitAfterSeq returns an iterator over subslices of self split after each
+// instance of sep. The iterator yields the same subslices that would be
+// returned by self.[SplitAfter](sep), but without constructing the Slice.
+// It returns a single-use iterator.
+func (self Bytes) SplitAfterSeq(sep []byte) iter.Seq[Bytes] {
+	return wrapSeq(bytes.SplitAfterSeq(self.Value(), sep))
+}
+
+// SplitN slices self into subslices separated by sep and returns a Slice of
+// the subslices between those separators.
+//
+// The count determines the number of subslices to return:
+//
+// - n > 0: at most n subslices; the last subslice will be the unsplit remainder;
+// - n == 0: the result is empty (zero subslices);
+// - n < 0: all subslices.
+//
+// To split around the first instance of a separator, see [Cut].
+func (self Bytes) SplitN(sep []byte, n int) Slice.Slice[Bytes] {
+	return wrapAll(bytes.SplitN(self.Value(), sep, n))
+}
+
+// SplitSeq returns an iterator over all subslices of self separated by sep.
+// The iterator yields the same subslices that would be returned by
+// self.[Split](sep), but without constructing the Slice. It returns a
+// single-use iterator.
+func (self Bytes) SplitSeq(sep []byte) iter.Seq[Bytes] {
+	return wrapSeq(bytes.SplitSeq(self.Value(), sep))
+}
+
+// ToLower returns a copy of self with all Unicode letters mapped to their lower case
+func (self Bytes) ToLower() Bytes {
+	return New(bytes.ToLower(self.Value()))
+}
+
+// ToLowerSpecial returns a copy of self with all Unicode letters mapped
+// to their lower case using the case mapping specified by c.
+func (self Bytes) ToLowerSpecial(c unicode.SpecialCase) Bytes {
+	return New(bytes.ToLowerSpecial(c, self.Value()))
+}
+
+// ToTitle returns a copy of self with all Unicode letters
+// mapped to their Unicode title case.
+func (self Bytes) ToTitle() Bytes {
+	return New(bytes.ToTitle(self.Value()))
+}
+
+// ToTitleSpecial returns a copy of self with all Unicode letters mapped
+// to their Unicode title case, giving priority to the special casing rules.
+func (self Bytes) ToTitleSpecial(c unicode.SpecialCase) Bytes {
+	return New(bytes.ToTitleSpecial(c, self.Value()))
+}
+
+// ToUpper returns a copy of self with all Unicode letters mapped to their upper case
+func (self Bytes) ToUpper() Bytes {
+	return New(bytes.ToUpper(self.Value()))
+}
+
+// ToUpperSpecial returns a copy of self with all Unicode letters mapped
+// to their upper case using the case mapping specified by c.
+func (self Bytes) ToUpperSpecial(c unicode.SpecialCase) Bytes {
+	return New(bytes.ToUpperSpecial(c, self.Value()))
+}
+
+// ToValidUTF8 returns a copy of self with each run of invalid UTF-8
+// byte sequences replaced by replacement, which may be empty.
+func (self Bytes) ToValidUTF8(replacement []byte) Bytes {
+	return New(bytes.ToValidUTF8(self.Value(), replacement))
+}
+
+// Trim return the subslice of self with all leading and trailing
+// characters in cutset removed
+func (self Bytes) Trim(cutset string) Bytes {
+	return New(bytes.Trim(self.Value(), cutset))
+}
+
+// TrimFunc return the subslice of self with all leading and trailing
+// characters satisfying f(c) removed
+func (self Bytes) TrimFunc(f func(rune) bool) Bytes {
+	return New(bytes.TrimFunc(self.Value(), f))
+}
+
+// TrimLeft return the subslice of self with all leading
+// characters in cutset removed
+func (self Bytes) TrimLeft(cutset string) Bytes {
+	return New(bytes.TrimLeft(self.Value(), cutset))
+}
+
+// TrimLeftFunc return the subslice of self with all leading
+// characters satisfying f(c) removed
+func (self Bytes) TrimLeftFunc(f func(rune) bool) Bytes {
+	return New(bytes.TrimLeftFunc(self.Value(), f))
+}
+
+// TrimPrefix returns the subslice of self with given prefix removed.
+// If the prefix is not found in self, it is returned as it is
+func (self Bytes) TrimPrefix(prefix []byte) Bytes {
+	return New(bytes.TrimPrefix(self.Value(), prefix))
+}
+
+// TrimRight return the subslice of self with all trailing
+// characters in cutset removed
+func (self Bytes) TrimRight(cutset string) Bytes {
+	return New(bytes.TrimRight(self.Value(), cutset))
+}
+
+// TrimRightFunc return the subslice of self with all trailing
+// characters satisfying f(c) removed
+func (self Bytes) TrimRightFunc(f func(rune) bool) Bytes {
+	return New(bytes.TrimRightFunc(self.Value(), f))
+}
+
+// TrimSpace return the subslice of self with all leading
+// and trailing whitespaces removed, as defined in Unicode
+func (self Bytes) TrimSpace() Bytes {
+	return New(bytes.TrimSpace(self.Value()))
+}
+
+// TrimSuffix returns the subslice of self with given suffix removed.
+// If the suffix is not found in self, it is returned as it is
+func (self Bytes) TrimSuffix(suffix []byte) Bytes {
+	return New(bytes.TrimSuffix(self.Value(), suffix))
+}
@@ -0,0 +1,51 @@
+package Bytes
+
+import (
+	"testing"
+
+	"github.com/harishtpj/klassy/Slice"
+	"github.com/harishtpj/klassy/String"
+)
+
+func TestSliceHelpersCompareContents(t *testing.T) {
+	fields := New([]byte("a x b")).Fields()
+	x := New([]byte("x"))
+	if fields.Contains(x) {
+		t.Errorf("Slice.Contains matched a view of other memory")
+	}
+	if !SliceContains(fields, x) {
+		t.Errorf("SliceContains(%v, x) = false", fields.Items)
+	}
+	if got := SliceIndex(fields, x); got != 1 {
+		t.Errorf("SliceIndex = %d, want 1", got)
+	}
+	if got := SliceIndex(fields, New([]byte("y"))); got != -1 {
+		t.Errorf("SliceIndex of a missing element = %d, want -1", got)
+	}
+	other := Slice.New([]Bytes{FromString("a"), FromString("x"), FromString("b")})
+	if !SlicesEqual(fields, other) {
+		t.Errorf("SlicesEqual(%v, %v) = false", fields.Items, other.Items)
+	}
+	if SlicesEqual(fields, Slice.New([]Bytes{FromString("a")})) {
+		t.Errorf("SlicesEqual of different lengths = true")
+	}
+}
+
+func TestViewAndCopy(t *testing.T) {
+	data := []byte("hello")
+	view := New(data)
+	clone := view.Clone()
+	data[0] = 'j'
+	if view.String() != "jello" || clone.String() != "hello" {
+		t.Errorf("view = %q, clone = %q", view, clone)
+	}
+	if view == clone || !New([]byte("jello")).Equal(view) {
+		t.Errorf("== should compare identity and Equal contents")
+	}
+	if got := FromString(String.New("abc")).ToString(); got != "abc" {
+		t.Errorf("FromString round trip = %q", got)
+	}
+	if (Bytes{}).Value() != nil || (Bytes{}).Length() != 0 {
+		t.Errorf("zero Bytes is not empty")
+	}
+}
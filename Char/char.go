@@ -0,0 +1,353 @@
+// package Char provides a custom Char type with chainable methods
+// with API similar to that of the standard library's unicode package,
+// and composable character predicates.
+package Char
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// type Char is alias for native rune
+type Char rune
+
+// New return a new instance of the Char type
+func New(r rune) Char {
+	return Char(r)
+}
+
+// Value return the original underlying rune value
+func (self Char) Value() rune {
+	return rune(self)
+}
+
+// String returns the UTF-8 encoding of self
+func (self Char) String() string {
+	return string(self.Value())
+}
+
+// Length returns the number of bytes needed to encode self in UTF-8,
+// or -1 if self is not a valid Unicode code point
+func (self Char) Length() int {
+	return utf8.RuneLen(self.Value())
+}
+
+// IsValid reports whether self is a valid Unicode code point which can be
+// encoded in UTF-8
+func (self Char) IsValid() bool {
+	return utf8.ValidRune(self.Value())
+}
+
+// Is reports whether self satisfies the predicate p
+func (self Char) Is(p Predicate) bool {
+	return p(self.Value())
+}
+
+// In reports whether self is a member of one of the ranges
+func (self Char) In(ranges ...*unicode.RangeTable) bool {
+	return unicode.In(self.Value(), ranges...)
+}
+
+// IsControl reports whether self is a control character
+func (self Char) IsControl() bool {
+	return unicode.IsControl(self.Value())
+}
+
+// IsDigit reports whether self is a decimal digit
+func (self Char) IsDigit() bool {
+	return unicode.IsDigit(self.Value())
+}
+
+// IsGraphic reports whether self is defined as a Graphic by Unicode:
+// letters, marks, numbers, punctuation, symbols and spaces
+func (self Char) IsGraphic() bool {
+	return unicode.IsGraphic(self.Value())
+}
+
+// IsLetter reports whether self is a letter
+func (self Char) IsLetter() bool {
+	return unicode.IsLetter(self.Value())
+}
+
+// IsLower reports whether self is a lower case letter
+func (self Char) IsLower() bool {
+	return unicode.IsLower(self.Value())
+}
+
+// IsMark reports whether self is a mark character, such as a combining accent
+func (self Char) IsMark() bool {
+	return unicode.IsMark(self.Value())
+}
+
+// IsNumber reports whether self is a number, including digits,
+// roman numerals and fractions
+func (self Char) IsNumber() bool {
+	return unicode.IsNumber(self.Value())
+}
+
+// IsPrint reports whether self is defined as printable by Go: graphic
+// characters with the ASCII space as the only spacing character
+func (self Char) IsPrint() bool {
+	return unicode.IsPrint(self.Value())
+}
+
+// IsPunct reports whether self is a Unicode punctuation character
+func (self Char) IsPunct() bool {
+	return unicode.IsPunct(self.Value())
+}
+
+// IsSpace reports whether self is a white space character, as defined
+// by unicode.IsSpace
+func (self Char) IsSpace() bool {
+	return unicode.IsSpace(self.Value())
+}
+
+// IsSymbol reports whether self is a symbolic character
+func (self Char) IsSymbol() bool {
+	return unicode.IsSymbol(self.Value())
+}
+
+// IsTitle reports whether self is a title case letter
+func (self Char) IsTitle() bool {
+	return unicode.IsTitle(self.Value())
+}
+
+// IsUpper reports whether self is an upper case letter
+func (self Char) IsUpper() bool {
+	return unicode.IsUpper(self.Value())
+}
+
+// SimpleFold iterates over Unicode code points equivalent under the
+// Unicode-defined simple case folding. It returns the smallest Char
+// greater than self which is equivalent to it, wrapping around to the
+// smallest one if there is none, so 'A' gives 'a' and 'a' gives 'A'.
+func (self Char) SimpleFold() Char {
+	return New(unicode.SimpleFold(self.Value()))
+}
+
+// ToLower maps self to lower case
+func (self Char) ToLower() Char {
+	return New(unicode.ToLower(self.Value()))
+}
+
+// ToTitle maps self to title case
+func (self Char) ToTitle() Char {
+	return New(unicode.ToTitle(self.Value()))
+}
+
+// ToUpper maps self to upper case
+func (self Char) ToUpper() Char {
+	return New(unicode.ToUpper(self.Value()))
+}
+
+// wideRanges are the East Asian Wide and Fullwidth ranges, including the
+// emoji presented as wide characters by terminals
+var wideRanges = [][2]rune{
+	{0x1100, 0x115F}, {0x231A, 0x231B}, {0x2329, 0x232A}, {0x23E9, 0x23EC},
+	{0x23F0, 0x23F0}, {0x23F3, 0x23F3}, {0x25FD, 0x25FE}, {0x2614, 0x2615},
+	{0x2648, 0x2653}, {0x267F, 0x267F}, {0x2693, 0x2693}, {0x26A1, 0x26A1},
+	{0x26AA, 0x26AB}, {0x26BD, 0x26BE}, {0x26C4, 0x26C5}, {0x26CE, 0x26CE},
+	{0x26D4, 0x26D4}, {0x26EA, 0x26EA}, {0x26F2, 0x26F3}, {0x26F5, 0x26F5},
+	{0x26FA, 0x26FA}, {0x26FD, 0x26FD}, {0x2705, 0x2705}, {0x270A, 0x270B},
+	{0x2728, 0x2728}, {0x274C, 0x274C}, {0x274E, 0x274E}, {0x2753, 0x2755},
+	{0x2757, 0x2757}, {0x2795, 0x2797}, {0x27B0, 0x27B0}, {0x27BF, 0x27BF},
+	{0x2B1B, 0x2B1C}, {0x2B50, 0x2B50}, {0x2B55, 0x2B55}, {0x2E80, 0x303E},
+	{0x3041, 0x33FF}, {0x3400, 0x4DBF}, {0x4E00, 0x9FFF}, {0xA000, 0xA4CF},
+	{0xA960, 0xA97F}, {0xAC00, 0xD7A3}, {0xF900, 0xFAFF}, {0xFE10, 0xFE19},
+	{0xFE30, 0xFE6F}, {0xFF00, 0xFF60}, {0xFFE0, 0xFFE6}, {0x16FE0, 0x16FE4},
+	{0x17000, 0x18AFF}, {0x1B000, 0x1B16F}, {0x1F004, 0x1F004}, {0x1F0CF, 0x1F0CF},
+	{0x1F18E, 0x1F18E}, {0x1F191, 0x1F19A}, {0x1F200, 0x1F251}, {0x1F300, 0x1F64F},
+	{0x1F680, 0x1F6FF}, {0x1F7E0, 0x1F7EB}, {0x1F900, 0x1F9FF}, {0x1FA70, 0x1FAFF},
+	{0x20000, 0x2FFFD}, {0x30000, 0x3FFFD},
+}
+
+// Width returns the number of terminal columns self occupies: 0 for
+// control characters, combining marks and zero-width format characters,
+// 2 for East Asian wide and fullwidth characters and most emoji, and 1 for
+// everything else.
+func (self Char) Width() int {
+	r := self.Value()
+	switch {
+	case r == 0 || unicode.IsControl(r):
+		return 0
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf) || 0x1160 <= r && r <= 0x11FF:
+		// Hangul medial vowels and final consonants combine with the initial
+		return 0
+	}
+	_, found := slices.BinarySearchFunc(wideRanges, r, func(rng [2]rune, r rune) int {
+		switch {
+		case rng[1] < r:
+			return -1
+		case rng[0] > r:
+			return 1
+		}
+		return 0
+	})
+	if found {
+		return 2
+	}
+	return 1
+}
+
+// categoryNames are the names of the Unicode general categories in
+// unicode.Categories, such as "Lu" and "Nd", in sorted order. The
+// one-letter major classes and the "LC" group of cased letters are left out.
+var categoryNames = sortedKeys(unicode.Categories, func(name string) bool { return len(name) == 2 && name != "LC" })
+
+// scriptNames are the names of the scripts in unicode.Scripts in sorted order
+var scriptNames = sortedKeys(unicode.Scripts, nil)
+
+// sortedKeys returns the keys of tables for which keep returns true, sorted
+func sortedKeys(tables map[string]*unicode.RangeTable, keep func(string) bool) []string {
+	names := make([]string, 0, len(tables))
+	for name := range tables {
+		if keep == nil || keep(name) {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Category returns the two-letter Unicode general category of self, such as
+// "Lu" for an upper case letter or "Nd" for a decimal digit. Unassigned
+// code points give "Cn".
+func (self Char) Category() string {
+	for _, name := range categoryNames {
+		if unicode.Is(unicode.Categories[name], self.Value()) {
+			return name
+		}
+	}
+	return "Cn"
+}
+
+// Script returns the name of the Unicode script of self, such as "Latin",
+// "Greek" or "Han". Characters shared by several scripts, such as digits
+// and punctuation, give "Common", combining marks which take the script of
+// the preceding character give "Inherited", and unassigned code points
+// give "Unknown".
+func (self Char) Script() string {
+	for _, name := range scriptNames {
+		if unicode.Is(unicode.Scripts[name], self.Value()) {
+			return name
+		}
+	}
+	return "Unknown"
+}
+
+// Jamo short names used to build the names of Hangul syllables
+var (
+	jamoLeading  = []string{"G", "GG", "N", "D", "DD", "R", "M", "B", "BB", "S", "SS", "", "J", "JJ", "C", "K", "T", "P", "H"}
+	jamoVowel    = []string{"A", "AE", "YA", "YAE", "EO", "E", "YEO", "YE", "O", "WA", "WAE", "OE", "YO", "U", "WEO", "WE", "WI", "YU", "EU", "YI", "I"}
+	jamoTrailing = []string{"", "G", "GG", "GS", "N", "NJ", "NH", "D", "L", "LG", "LM", "LB", "LS", "LT", "LP", "LH", "M", "B", "BS", "S", "SS", "NG", "J", "C", "K", "T", "P", "H"}
+)
+
+// Name returns the Unicode name of self, such as "LATIN SMALL LETTER E WITH
+// ACUTE" for 'é'. As the standard library has no table of names, only the
+// printable characters of ASCII and Latin-1 and the characters whose names
+// are derived algorithmically, the CJK ideographs and Hangul syllables, are
+// named; Name returns "" for every other character and for control
+// characters, which have no name.
+func (self Char) Name() string {
+	r := self.Value()
+	switch {
+	case 0 <= r && r < rune(len(latin1Names)):
+		return latin1Names[r]
+	case 0xAC00 <= r && r <= 0xD7A3:
+		s := int(r - 0xAC00)
+		l, v, t := s/(21*28), s%(21*28)/28, s%28
+		return "HANGUL SYLLABLE " + jamoLeading[l] + jamoVowel[v] + jamoTrailing[t]
+	case (0xF900 <= r && r <= 0xFAFF || 0x2F800 <= r && r <= 0x2FA1F) && unicode.Is(unicode.Ideographic, r):
+		return fmt.Sprintf("CJK COMPATIBILITY IDEOGRAPH-%04X", r)
+	case unicode.Is(unicode.Unified_Ideograph, r):
+		return fmt.Sprintf("CJK UNIFIED IDEOGRAPH-%04X", r)
+	}
+	return ""
+}
+
+// Predicate reports whether a character has some property. Predicates can
+// be combined with [Predicate.Or], [Predicate.And] and [Predicate.Not], and
+// can be passed directly to functions taking a func(rune) bool, such as
+// String.FieldsFunc, String.TrimFunc and String.IndexFunc:
+//
+//	s.TrimFunc(Char.Letter.Or(Char.Digit).Not())
+type Predicate func(rune) bool
+
+// Predicates for the character classes of the unicode package
+var (
+	Control Predicate = unicode.IsControl
+	Digit   Predicate = unicode.IsDigit
+	Graphic Predicate = unicode.IsGraphic
+	Letter  Predicate = unicode.IsLetter
+	Lower   Predicate = unicode.IsLower
+	Mark    Predicate = unicode.IsMark
+	Number  Predicate = unicode.IsNumber
+	Print   Predicate = unicode.IsPrint
+	Punct   Predicate = unicode.IsPunct
+	Space   Predicate = unicode.IsSpace
+	Symbol  Predicate = unicode.IsSymbol
+	Title   Predicate = unicode.IsTitle
+	Upper   Predicate = unicode.IsUpper
+)
+
+// Any returns a Predicate matching any of the characters in chars
+func Any(chars string) Predicate {
+	return func(r rune) bool {
+		return strings.ContainsRune(chars, r)
+	}
+}
+
+// Between returns a Predicate matching the characters from lo to hi inclusive
+func Between(lo, hi rune) Predicate {
+	return func(r rune) bool {
+		return lo <= r && r <= hi
+	}
+}
+
+// InRanges returns a Predicate matching the members of any of the ranges,
+// such as unicode.Greek or unicode.Han
+func InRanges(ranges ...*unicode.RangeTable) Predicate {
+	return func(r rune) bool {
+		return unicode.In(r, ranges...)
+	}
+}
+
+// Or returns a Predicate matching the characters matched by p or any of others
+func (p Predicate) Or(others ...Predicate) Predicate {
+	return func(r rune) bool {
+		if p(r) {
+			return true
+		}
+		for _, other := range others {
+			if other(r) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// And returns a Predicate matching the characters matched by p and all of others
+func (p Predicate) And(others ...Predicate) Predicate {
+	return func(r rune) bool {
+		if !p(r) {
+			return false
+		}
+		for _, other := range others {
+			if !other(r) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Not returns a Predicate matching the characters not matched by p
+func (p Predicate) Not() Predicate {
+	return func(r rune) bool {
+		return !p(r)
+	}
+}
@@ -3,6 +3,8 @@
 package Slice
 
 import (
+	"errors"
+	"fmt"
 	"iter"
 	"slices"
 )
@@ -136,6 +138,20 @@ func (self *Slice[T]) Reverse() {
 	slices.Reverse(self.Items)
 }
 
+// SortFunc sorts the Slice in place in ascending order as determined by
+// the cmp function. cmp(a, b) should return a negative number when a < b,
+// a positive number when a > b and zero when a == b. SortFunc is not
+// guaranteed to be stable.
+func (self *Slice[T]) SortFunc(cmp func(a, b T) int) {
+	slices.SortFunc(self.Items, cmp)
+}
+
+// SortStableFunc sorts the Slice in place while keeping the original order
+// of equal elements, using cmp to compare elements in the same way as [SortFunc].
+func (self *Slice[T]) SortStableFunc(cmp func(a, b T) int) {
+	slices.SortStableFunc(self.Items, cmp)
+}
+
 // Values returns an iterator that yields the slice elements in order.
 func (self Slice[T]) Values() iter.Seq[T] {
 	return slices.Values(self.Items)
@@ -158,3 +174,37 @@ func MapTo[T, U comparable](self Slice[T], fn func(T) U) Slice[U] {
 	return New(result)
 }
 
+// IndexError records an error returned for the element at Index of a Slice
+type IndexError struct {
+	Index int
+	Err   error
+}
+
+// Error implements the error interface
+func (e *IndexError) Error() string {
+	return fmt.Sprintf("index %d: %v", e.Index, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *IndexError) Unwrap() error {
+	return e.Err
+}
+
+// TryMapTo applies the given fallible function to each element and returns a
+// new Slice with the transformed elements of type U. Every element is
+// converted even if some fail; the zero value of U is stored for failed
+// elements and their errors are returned joined together, each wrapped in an
+// *[IndexError] recording its position.
+func TryMapTo[T, U comparable](self Slice[T], fn func(T) (U, error)) (Slice[U], error) {
+	result := make([]U, self.Length())
+	var errs []error
+	for i, v := range self.Items {
+		u, err := fn(v)
+		if err != nil {
+			errs = append(errs, &IndexError{Index: i, Err: err})
+			continue
+		}
+		result[i] = u
+	}
+	return New(result), errors.Join(errs...)
+}
@@ -0,0 +1,357 @@
+package String
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync/atomic"
+
+	"github.com/harishtpj/klassy/Char"
+)
+
+// ColorLevel is the colour capability of the terminal that styled text is
+// written to. Colours are downsampled to the closest one available at the
+// current level, and no escape sequences are emitted at [ColorNone].
+type ColorLevel int32
+
+const (
+	ColorAuto      ColorLevel = iota - 1 // detect the level from the environment
+	ColorNone                            // plain text without any escape sequences
+	Color16                              // the 16 basic ANSI colours
+	Color256                             // the xterm 256 colour palette
+	ColorTrueColor                       // 24-bit RGB colour
+)
+
+// colorLevel holds the level set with SetColorLevel
+var colorLevel atomic.Int32
+
+func init() {
+	colorLevel.Store(int32(ColorAuto))
+}
+
+// SetColorLevel sets the colour capability used by the styling methods of
+// String, overriding the one detected from the environment. Passing
+// [ColorAuto] restores detection.
+func SetColorLevel(level ColorLevel) {
+	colorLevel.Store(int32(level))
+}
+
+// CurrentColorLevel returns the colour capability used by the styling
+// methods. Unless set with [SetColorLevel], it is detected from the
+// environment: a non-empty NO_COLOR or TERM=dumb disables styling,
+// COLORTERM=truecolor or 24bit selects true colour, a TERM ending in
+// "256color" selects 256 colours and anything else selects 16 colours.
+func CurrentColorLevel() ColorLevel {
+	if level := ColorLevel(colorLevel.Load()); level != ColorAuto {
+		return level
+	}
+	return detectColorLevel()
+}
+
+// detectColorLevel derives the colour capability from environment variables
+func detectColorLevel() ColorLevel {
+	if os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return ColorNone
+	}
+	switch strings.ToLower(os.Getenv("COLORTERM")) {
+	case "truecolor", "24bit":
+		return ColorTrueColor
+	}
+	if strings.HasSuffix(os.Getenv("TERM"), "256color") {
+		return Color256
+	}
+	return Color16
+}
+
+// Color is a terminal colour: one of the 16 basic ANSI colours, an entry of
+// the 256 colour palette or a 24-bit RGB value.
+type Color uint32
+
+// The 16 basic ANSI colours
+const (
+	Black Color = iota
+	Red
+	Green
+	Yellow
+	Blue
+	Magenta
+	Cyan
+	White
+	BrightBlack
+	BrightRed
+	BrightGreen
+	BrightYellow
+	BrightBlue
+	BrightMagenta
+	BrightCyan
+	BrightWhite
+)
+
+// rgbFlag marks a Color holding a 24-bit RGB value rather than a palette index
+const rgbFlag Color = 1 << 24
+
+// PaletteColor returns the colour at index n of the 256 colour palette
+func PaletteColor(n uint8) Color {
+	return Color(n)
+}
+
+// RGBColor returns the 24-bit colour with the given components
+func RGBColor(r, g, b uint8) Color {
+	return rgbFlag | Color(r)<<16 | Color(g)<<8 | Color(b)
+}
+
+// rgb returns the red, green and blue components of c, using the xterm
+// default palette for indexed colours
+func (c Color) rgb() (r, g, b uint8) {
+	if c&rgbFlag != 0 {
+		return uint8(c >> 16), uint8(c >> 8), uint8(c)
+	}
+	n := uint8(c)
+	switch {
+	case n < 16:
+		p := basicPalette[n]
+		return p[0], p[1], p[2]
+	case n < 232:
+		n -= 16
+		return cubeLevels[n/36], cubeLevels[n/6%6], cubeLevels[n%6]
+	}
+	gray := 8 + 10*(n-232)
+	return gray, gray, gray
+}
+
+// basicPalette holds the xterm default values of the 16 basic colours
+var basicPalette = [16][3]uint8{
+	{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0},
+	{0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+	{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0},
+	{92, 92, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+// cubeLevels holds the component values of the 6x6x6 colour cube
+var cubeLevels = [6]uint8{0, 95, 135, 175, 215, 255}
+
+// sgr returns the Select Graphic Rendition parameters selecting c as the
+// foreground, or background if bg is set, at the given level
+func (c Color) sgr(level ColorLevel, bg bool) string {
+	base := 30
+	if bg {
+		base = 40
+	}
+	if c&rgbFlag == 0 && c < 16 {
+		if c < 8 {
+			return fmt.Sprint(base + int(c))
+		}
+		return fmt.Sprint(base + 60 + int(c-8))
+	}
+
+	switch level {
+	case ColorTrueColor:
+		if c&rgbFlag != 0 {
+			r, g, b := c.rgb()
+			return fmt.Sprintf("%d;2;%d;%d;%d", base+8, r, g, b)
+		}
+		return fmt.Sprintf("%d;5;%d", base+8, uint8(c))
+	case Color256:
+		return fmt.Sprintf("%d;5;%d", base+8, c.to256())
+	}
+	return Color(c.to16()).sgr(level, bg)
+}
+
+// to256 returns the palette index closest to c
+func (c Color) to256() uint8 {
+	if c&rgbFlag == 0 {
+		return uint8(c)
+	}
+	r, g, b := c.rgb()
+	cube := func(v uint8) uint8 {
+		if v < 48 {
+			return 0
+		}
+		if v < 115 {
+			return 1
+		}
+		return (v - 35) / 40
+	}
+	ci := 16 + 36*cube(r) + 6*cube(g) + cube(b)
+
+	avg := (int(r) + int(g) + int(b)) / 3
+	gi := uint8(232)
+	if avg > 238 {
+		gi = 255
+	} else if avg > 8 {
+		gi = uint8(232 + (avg-3)/10)
+	}
+
+	if colorDistance(c, Color(ci)) <= colorDistance(c, Color(gi)) {
+		return ci
+	}
+	return gi
+}
+
+// to16 returns the basic colour closest to c
+func (c Color) to16() uint8 {
+	if c&rgbFlag == 0 && c < 16 {
+		return uint8(c)
+	}
+	best, bestDist := uint8(0), -1
+	for i := range uint8(16) {
+		if d := colorDistance(c, Color(i)); bestDist < 0 || d < bestDist {
+			best, bestDist = i, d
+		}
+	}
+	return best
+}
+
+// colorDistance returns the squared euclidean distance between a and b
+func colorDistance(a, b Color) int {
+	ar, ag, ab := a.rgb()
+	br, bg, bb := b.rgb()
+	dr, dg, db := int(ar)-int(br), int(ag)-int(bg), int(ab)-int(bb)
+	return dr*dr + dg*dg + db*db
+}
+
+// styled wraps self in the SGR sequence on and the sequence off, unless
+// styling is disabled
+func (self String) styled(on, off string) String {
+	if CurrentColorLevel() == ColorNone {
+		return self
+	}
+	return New("\x1b[" + on + "m" + self.Value() + "\x1b[" + off + "m")
+}
+
+// Color returns self styled with the foreground colour fg.
+func (self String) Color(fg Color) String {
+	level := CurrentColorLevel()
+	if level == ColorNone {
+		return self
+	}
+	return self.styled(fg.sgr(level, false), "39")
+}
+
+// Bg returns self styled with the background colour bg.
+func (self String) Bg(bg Color) String {
+	level := CurrentColorLevel()
+	if level == ColorNone {
+		return self
+	}
+	return self.styled(bg.sgr(level, true), "49")
+}
+
+// RGB returns self styled with the 24-bit foreground colour r, g, b,
+// downsampled if the terminal does not support true colour.
+func (self String) RGB(r, g, b uint8) String {
+	return self.Color(RGBColor(r, g, b))
+}
+
+// Bold returns self styled in bold.
+func (self String) Bold() String {
+	return self.styled("1", "22")
+}
+
+// Italic returns self styled in italics.
+func (self String) Italic() String {
+	return self.styled("3", "23")
+}
+
+// Underline returns self styled with an underline.
+func (self String) Underline() String {
+	return self.styled("4", "24")
+}
+
+// Hyperlink returns self as the text of a terminal hyperlink to url, using
+// the OSC 8 escape sequence.
+func (self String) Hyperlink(url string) String {
+	if CurrentColorLevel() == ColorNone {
+		return self
+	}
+	return New("\x1b]8;;" + url + "\x1b\\" + self.Value() + "\x1b]8;;\x1b\\")
+}
+
+// StripANSI returns a copy of self with all ANSI escape sequences removed,
+// including CSI sequences such as colours and cursor movement and OSC
+// sequences such as hyperlinks and window titles.
+func (self String) StripANSI() String {
+	s := self.Value()
+	if !strings.ContainsAny(s, "\x1b\u009b\u009d") {
+		return self
+	}
+
+	var buf strings.Builder
+	buf.Grow(len(s))
+	for i := 0; i < len(s); {
+		if n := ansiSequenceLen(s[i:]); n > 0 {
+			i += n
+			continue
+		}
+		buf.WriteByte(s[i])
+		i++
+	}
+	return New(buf.String())
+}
+
+// ansiSequenceLen returns the length in bytes of the escape sequence at the
+// start of s, or 0 if s does not start with one
+func ansiSequenceLen(s string) int {
+	var body int // offset of the first byte after the introducer
+	var osc bool
+	switch {
+	case strings.HasPrefix(s, "\x1b["):
+		body = 2
+	case strings.HasPrefix(s, "\u009b"):
+		body = len("\u009b")
+	case strings.HasPrefix(s, "\x1b]"):
+		body, osc = 2, true
+	case strings.HasPrefix(s, "\u009d"):
+		body, osc = len("\u009d"), true
+	case strings.HasPrefix(s, "\x1b") && len(s) > 1:
+		// Two character sequences such as ESC 7, with optional intermediates
+		i := 1
+		for i < len(s) && 0x20 <= s[i] && s[i] <= 0x2f {
+			i++
+		}
+		if i < len(s) && 0x30 <= s[i] && s[i] <= 0x7e {
+			return i + 1
+		}
+		return 1
+	default:
+		return 0
+	}
+
+	if osc {
+		// Operating system commands end with BEL or ST (ESC \)
+		for i := body; i < len(s); i++ {
+			switch {
+			case s[i] == '\a':
+				return i + 1
+			case s[i] == 0x1b && i+1 < len(s) && s[i+1] == '\\':
+				return i + 2
+			case strings.HasPrefix(s[i:], "\u009c"):
+				return i + len("\u009c")
+			}
+		}
+		return len(s)
+	}
+
+	// Control sequences are parameter and intermediate bytes ended by a final byte
+	for i := body; i < len(s); i++ {
+		if 0x40 <= s[i] && s[i] <= 0x7e {
+			return i + 1
+		}
+		if s[i] < 0x20 || s[i] > 0x3f {
+			return i
+		}
+	}
+	return len(s)
+}
+
+// VisibleLength returns the number of terminal columns self takes up when
+// printed, ignoring escape sequences. Control characters and zero-width
+// combining marks take none, and East Asian wide characters and most emoji
+// take two, as measured by [Char.Char.Width].
+func (self String) VisibleLength() int {
+	n := 0
+	for _, r := range self.StripANSI().Value() {
+		n += Char.New(r).Width()
+	}
+	return n
+}
@@ -0,0 +1,37 @@
+package String
+
+import "testing"
+
+func TestVisibleLength(t *testing.T) {
+	tests := []struct {
+		text String
+		want int
+	}{
+		{"hello", 5},
+		{"\x1b[1;31mred\x1b[0m", 3},
+		{"日本語", 6},
+		{"é", 1},
+		{"go 🚀", 5},
+		{"\x1b]8;;https://go.dev\x1b\\link\x1b]8;;\x1b\\", 4},
+		{"tab\there", 7},
+	}
+	for _, tt := range tests {
+		if got := tt.text.VisibleLength(); got != tt.want {
+			t.Errorf("%q.VisibleLength() = %d, want %d", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestStripANSI(t *testing.T) {
+	level := CurrentColorLevel()
+	defer SetColorLevel(level)
+	SetColorLevel(ColorTrueColor)
+
+	styled := New("warn").Bold().RGB(255, 128, 0).Hyperlink("https://example.com")
+	if styled == "warn" {
+		t.Fatal("styling added no escape sequences")
+	}
+	if got := styled.StripANSI(); got != "warn" {
+		t.Errorf("StripANSI() = %q, want %q", got, "warn")
+	}
+}
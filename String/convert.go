@@ -0,0 +1,283 @@
+package String
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/harishtpj/klassy/Slice"
+)
+
+// ConvError is returned when a String cannot be converted to another type.
+// It records the conversion that failed and the original text.
+type ConvError struct {
+	Func string // the failing method, such as "ToInt"
+	Text string // the original text
+	Err  error  // the reason the conversion failed
+}
+
+// Error implements the error interface
+func (e *ConvError) Error() string {
+	return fmt.Sprintf("String.%s: parsing %q: %v", e.Func, e.Text, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *ConvError) Unwrap() error {
+	return e.Err
+}
+
+// convError wraps err in a *ConvError, unwrapping the redundant
+// *strconv.NumError which already holds the same text
+func convError(fn string, text String, err error) error {
+	if numErr, ok := err.(*strconv.NumError); ok {
+		err = numErr.Err
+	}
+	return &ConvError{Func: fn, Text: text.Value(), Err: err}
+}
+
+// ToInt interprets self, with surrounding white space removed, as an integer
+// in the given base (0, 2 to 36) and returns its value. If base is 0, the base
+// is implied by the prefix of the string, as in [strconv.ParseInt].
+func (self String) ToInt(base int) (int, error) {
+	n, err := strconv.ParseInt(self.TrimSpace().Value(), base, strconv.IntSize)
+	if err != nil {
+		return 0, convError("ToInt", self, err)
+	}
+	return int(n), nil
+}
+
+// ToInt64 is like [String.ToInt] but returns an int64.
+func (self String) ToInt64(base int) (int64, error) {
+	n, err := strconv.ParseInt(self.TrimSpace().Value(), base, 64)
+	if err != nil {
+		return 0, convError("ToInt64", self, err)
+	}
+	return n, nil
+}
+
+// ToUint is like [String.ToInt] but for unsigned integers, and returns a uint64.
+func (self String) ToUint(base int) (uint64, error) {
+	n, err := strconv.ParseUint(self.TrimSpace().Value(), base, 64)
+	if err != nil {
+		return 0, convError("ToUint", self, err)
+	}
+	return n, nil
+}
+
+// ToFloat64 interprets self, with surrounding white space removed, as a
+// floating-point number, accepting the syntax of [strconv.ParseFloat].
+func (self String) ToFloat64() (float64, error) {
+	f, err := strconv.ParseFloat(self.TrimSpace().Value(), 64)
+	if err != nil {
+		return 0, convError("ToFloat64", self, err)
+	}
+	return f, nil
+}
+
+// ToBool interprets self as a boolean. Besides the values accepted by
+// [strconv.ParseBool], the words "yes", "y", "on" and "no", "n", "off" are
+// accepted in any case, and surrounding white space is ignored.
+func (self String) ToBool() (bool, error) {
+	switch strings.ToLower(self.TrimSpace().Value()) {
+	case "1", "t", "true", "y", "yes", "on":
+		return true, nil
+	case "0", "f", "false", "n", "no", "off":
+		return false, nil
+	}
+	return false, convError("ToBool", self, strconv.ErrSyntax)
+}
+
+// ToDuration interprets self, with surrounding white space removed, as a
+// duration in the format accepted by [time.ParseDuration], such as "1h30m".
+func (self String) ToDuration() (time.Duration, error) {
+	d, err := time.ParseDuration(self.TrimSpace().Value())
+	if err != nil {
+		return 0, convError("ToDuration", self, err)
+	}
+	return d, nil
+}
+
+// defaultTimeLayouts are tried by ToTime when no layout is given
+var defaultTimeLayouts = []string{
+	time.RFC3339Nano,
+	time.DateTime,
+	time.DateOnly,
+	time.RFC1123Z,
+	time.RFC1123,
+	time.RFC850,
+	time.ANSIC,
+	time.TimeOnly,
+}
+
+// ToTime interprets self, with surrounding white space removed, as a time
+// using the first of layouts that matches. If no layout is given, RFC 3339,
+// [time.DateTime], [time.DateOnly], the RFC 1123 and RFC 850 formats,
+// [time.ANSIC] and [time.TimeOnly] are tried in that order. Times without a
+// zone are interpreted as UTC.
+func (self String) ToTime(layouts ...string) (time.Time, error) {
+	if len(layouts) == 0 {
+		layouts = defaultTimeLayouts
+	}
+	text := self.TrimSpace().Value()
+	var err error
+	for _, layout := range layouts {
+		var t time.Time
+		if t, err = time.Parse(layout, text); err == nil {
+			return t, nil
+		}
+	}
+	if len(layouts) > 1 {
+		err = fmt.Errorf("does not match any of %d layouts", len(layouts))
+	}
+	return time.Time{}, convError("ToTime", self, err)
+}
+
+// byteUnits maps lower-cased size suffixes to their multiplier
+var byteUnits = map[string]float64{
+	"":    1,
+	"b":   1,
+	"k":   1e3,
+	"kb":  1e3,
+	"m":   1e6,
+	"mb":  1e6,
+	"g":   1e9,
+	"gb":  1e9,
+	"t":   1e12,
+	"tb":  1e12,
+	"p":   1e15,
+	"pb":  1e15,
+	"e":   1e18,
+	"eb":  1e18,
+	"kib": 1 << 10,
+	"mib": 1 << 20,
+	"gib": 1 << 30,
+	"tib": 1 << 40,
+	"pib": 1 << 50,
+	"eib": 1 << 60,
+}
+
+// ToBytesSize interprets self as a size in bytes, such as "512", "10MiB" or
+// "1.5 GB". Units are case-insensitive; SI units (kB, MB, ...) are powers of
+// 1000 and IEC units (KiB, MiB, ...) are powers of 1024. Fractional sizes are
+// rounded to the nearest byte, and sizes may be negative, such as the
+// "-1.5 KiB" written by [HumanBytes] for a shrinking file.
+func (self String) ToBytesSize() (int64, error) {
+	text := self.TrimSpace().Value()
+	end := strings.IndexFunc(text, func(r rune) bool {
+		return !(r >= '0' && r <= '9' || r == '.' || r == '+' || r == '-')
+	})
+	if end < 0 {
+		end = len(text)
+	}
+	number, unit := text[:end], strings.ToLower(strings.TrimSpace(text[end:]))
+
+	mult, ok := byteUnits[unit]
+	if !ok {
+		return 0, convError("ToBytesSize", self, fmt.Errorf("unknown unit %q", text[end:]))
+	}
+	n, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, convError("ToBytesSize", self, err)
+	}
+	size := math.Round(n * mult)
+	if size >= math.MaxInt64 || size < math.MinInt64 {
+		return 0, convError("ToBytesSize", self, strconv.ErrRange)
+	}
+	return int64(size), nil
+}
+
+// ToIntOrDefault is like [String.ToInt] but returns def if self cannot be converted.
+func (self String) ToIntOrDefault(base int, def int) int {
+	if n, err := self.ToInt(base); err == nil {
+		return n
+	}
+	return def
+}
+
+// ToInt64OrDefault is like [String.ToInt64] but returns def if self cannot be converted.
+func (self String) ToInt64OrDefault(base int, def int64) int64 {
+	if n, err := self.ToInt64(base); err == nil {
+		return n
+	}
+	return def
+}
+
+// ToUintOrDefault is like [String.ToUint] but returns def if self cannot be converted.
+func (self String) ToUintOrDefault(base int, def uint64) uint64 {
+	if n, err := self.ToUint(base); err == nil {
+		return n
+	}
+	return def
+}
+
+// ToFloat64OrDefault is like [String.ToFloat64] but returns def if self cannot be converted.
+func (self String) ToFloat64OrDefault(def float64) float64 {
+	if f, err := self.ToFloat64(); err == nil {
+		return f
+	}
+	return def
+}
+
+// ToBoolOrDefault is like [String.ToBool] but returns def if self cannot be converted.
+func (self String) ToBoolOrDefault(def bool) bool {
+	if b, err := self.ToBool(); err == nil {
+		return b
+	}
+	return def
+}
+
+// ToDurationOrDefault is like [String.ToDuration] but returns def if self cannot be converted.
+func (self String) ToDurationOrDefault(def time.Duration) time.Duration {
+	if d, err := self.ToDuration(); err == nil {
+		return d
+	}
+	return def
+}
+
+// ToTimeOrDefault is like [String.ToTime] but returns def if self cannot be converted.
+func (self String) ToTimeOrDefault(def time.Time, layouts ...string) time.Time {
+	if t, err := self.ToTime(layouts...); err == nil {
+		return t
+	}
+	return def
+}
+
+// ToBytesSizeOrDefault is like [String.ToBytesSize] but returns def if self cannot be converted.
+func (self String) ToBytesSizeOrDefault(def int64) int64 {
+	if n, err := self.ToBytesSize(); err == nil {
+		return n
+	}
+	return def
+}
+
+// ToInts converts every element of s with [String.ToInt]. Failed elements
+// are left as zero and their errors are returned as described in [Slice.TryMapTo].
+func ToInts(s Slice.Slice[String], base int) (Slice.Slice[int], error) {
+	return Slice.TryMapTo(s, func(v String) (int, error) { return v.ToInt(base) })
+}
+
+// ToInt64s converts every element of s with [String.ToInt64]. Failed elements
+// are left as zero and their errors are returned as described in [Slice.TryMapTo].
+func ToInt64s(s Slice.Slice[String], base int) (Slice.Slice[int64], error) {
+	return Slice.TryMapTo(s, func(v String) (int64, error) { return v.ToInt64(base) })
+}
+
+// ToFloat64s converts every element of s with [String.ToFloat64]. Failed elements
+// are left as zero and their errors are returned as described in [Slice.TryMapTo].
+func ToFloat64s(s Slice.Slice[String]) (Slice.Slice[float64], error) {
+	return Slice.TryMapTo(s, String.ToFloat64)
+}
+
+// ToBools converts every element of s with [String.ToBool]. Failed elements
+// are left as false and their errors are returned as described in [Slice.TryMapTo].
+func ToBools(s Slice.Slice[String]) (Slice.Slice[bool], error) {
+	return Slice.TryMapTo(s, String.ToBool)
+}
+
+// ToDurations converts every element of s with [String.ToDuration]. Failed elements
+// are left as zero and their errors are returned as described in [Slice.TryMapTo].
+func ToDurations(s Slice.Slice[String]) (Slice.Slice[time.Duration], error) {
+	return Slice.TryMapTo(s, String.ToDuration)
+}
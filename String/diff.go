@@ -0,0 +1,581 @@
+package String
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/harishtpj/klassy/Slice"
+)
+
+// DiffOp is the kind of change recorded by a [DiffEdit]
+type DiffOp int
+
+const (
+	DiffEqual  DiffOp = iota // the token is present in both texts
+	DiffDelete               // the token is only present in the old text
+	DiffInsert               // the token is only present in the new text
+)
+
+// String returns the prefix used for the operation in unified diffs
+func (op DiffOp) String() string {
+	switch op {
+	case DiffDelete:
+		return "-"
+	case DiffInsert:
+		return "+"
+	}
+	return " "
+}
+
+// DiffGranularity selects the tokens that texts are split into before diffing
+type DiffGranularity int
+
+const (
+	DiffLines DiffGranularity = iota // lines including their terminating newline
+	DiffWords                        // runs of letters and digits, runs of spaces and single symbols
+	DiffRunes                        // single characters
+)
+
+// DiffEdit is a single step of an edit script turning one text into another.
+// OldIndex and NewIndex are the zero-based positions of the token in the old
+// and new token sequences. For an inserted token OldIndex is the position in
+// the old sequence before which it is inserted, and for a deleted token
+// NewIndex is likewise the position in the new sequence.
+type DiffEdit struct {
+	Op       DiffOp
+	Text     String
+	OldIndex int
+	NewIndex int
+}
+
+// Diff returns the shortest line-based edit script which turns self into
+// other, computed with the Myers difference algorithm. Lines keep their
+// terminating newlines, as yielded by [String.Lines].
+func (self String) Diff(other string) Slice.Slice[DiffEdit] {
+	return self.DiffBy(other, DiffLines)
+}
+
+// DiffBy is like [String.Diff] but splits self and other into tokens of the
+// given granularity, which allows word-level or rune-level comparisons.
+func (self String) DiffBy(other string, granularity DiffGranularity) Slice.Slice[DiffEdit] {
+	a := diffTokens(self.Value(), granularity)
+	b := diffTokens(other, granularity)
+	return Slice.New(myersDiff(a, b))
+}
+
+// diffTokens splits s into the tokens compared at the given granularity
+func diffTokens(s string, granularity DiffGranularity) []string {
+	var tokens []string
+	switch granularity {
+	case DiffRunes:
+		for i, r := range s {
+			tokens = append(tokens, s[i:i+utf8.RuneLen(r)])
+		}
+	case DiffWords:
+		for s != "" {
+			r, n := utf8.DecodeRuneInString(s)
+			var class func(rune) bool
+			switch {
+			case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_':
+				class = func(c rune) bool { return unicode.IsLetter(c) || unicode.IsDigit(c) || c == '_' }
+			case unicode.IsSpace(r):
+				class = unicode.IsSpace
+			}
+			if class != nil {
+				if end := strings.IndexFunc(s, func(c rune) bool { return !class(c) }); end >= 0 {
+					n = end
+				} else {
+					n = len(s)
+				}
+			}
+			tokens = append(tokens, s[:n])
+			s = s[n:]
+		}
+	default:
+		for line := range strings.Lines(s) {
+			tokens = append(tokens, line)
+		}
+	}
+	return tokens
+}
+
+// myersDiff returns the shortest edit script turning a into b, as described
+// in "An O(ND) Difference Algorithm and Its Variations" by Eugene W. Myers.
+// It uses the linear space refinement of section 4b, splitting the problem
+// at the middle of an optimal path and solving the halves recursively, so
+// memory stays proportional to the length of the inputs.
+func myersDiff(a, b []string) []DiffEdit {
+	edits := make([]DiffEdit, 0, len(a)+len(b))
+	myersRange(a, b, 0, 0, &edits)
+
+	// Within each block of changes, list the deletions before the insertions,
+	// as diff -u and git do
+	for i := 0; i < len(edits); {
+		if edits[i].Op == DiffEqual {
+			i++
+			continue
+		}
+		j := i
+		var deleted, inserted []DiffEdit
+		for ; j < len(edits) && edits[j].Op != DiffEqual; j++ {
+			if edits[j].Op == DiffDelete {
+				deleted = append(deleted, edits[j])
+			} else {
+				inserted = append(inserted, edits[j])
+			}
+		}
+		x, y := edits[i].OldIndex, edits[i].NewIndex
+		for n, e := range deleted {
+			edits[i+n] = DiffEdit{DiffDelete, e.Text, x + n, y}
+		}
+		for n, e := range inserted {
+			edits[i+len(deleted)+n] = DiffEdit{DiffInsert, e.Text, x + len(deleted), y + n}
+		}
+		i = j
+	}
+	return edits
+}
+
+// myersRange appends to edits the edit script turning a into b, which start
+// at offsets x0 and y0 of the original sequences
+func myersRange(a, b []string, x0, y0 int, edits *[]DiffEdit) {
+	// The common prefix and suffix never take part in the edit path
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		*edits = append(*edits, DiffEdit{DiffEqual, New(a[prefix]), x0 + prefix, y0 + prefix})
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix &&
+		a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	ma, mb := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+	mx, my := x0+prefix, y0+prefix
+
+	if x, y := myersBisect(ma, mb); x >= 0 {
+		myersRange(ma[:x], mb[:y], mx, my, edits)
+		myersRange(ma[x:], mb[y:], mx+x, my+y, edits)
+	} else {
+		for i, t := range ma {
+			*edits = append(*edits, DiffEdit{DiffDelete, New(t), mx + i, my})
+		}
+		for i, t := range mb {
+			*edits = append(*edits, DiffEdit{DiffInsert, New(t), mx + len(ma), my + i})
+		}
+	}
+
+	for i := range suffix {
+		x, y := len(a)-suffix+i, len(b)-suffix+i
+		*edits = append(*edits, DiffEdit{DiffEqual, New(a[x]), x0 + x, y0 + y})
+	}
+}
+
+// myersBisect runs the Myers search from both ends of a and b at once until
+// the paths meet, and returns the point where the forward path reached the
+// overlap. Both halves of an optimal path split there need fewer edits than
+// the whole. It returns -1, -1 if a or b is empty or they have nothing in
+// common, in which case everything is deleted and inserted.
+func myersBisect(a, b []string) (int, int) {
+	n, m := len(a), len(b)
+	if n == 0 || m == 0 {
+		return -1, -1
+	}
+	maxD := (n + m + 1) / 2
+	offset := maxD
+	// vf and vb hold the furthest x reached on each diagonal by the forward
+	// search and by the backward search, which runs on the reversed texts
+	vf := make([]int, 2*maxD+2)
+	vb := make([]int, 2*maxD+2)
+	for i := range vf {
+		vf[i], vb[i] = -1, -1
+	}
+	vf[offset+1], vb[offset+1] = 0, 0
+	delta := n - m
+	// With an odd delta the paths meet on a forward step, otherwise backward
+	front := delta%2 != 0
+	// Diagonals which ran off the edge of the grid are no longer searched
+	kfStart, kfEnd, kbStart, kbEnd := 0, 0, 0, 0
+
+	for d := 0; d < maxD; d++ {
+		for k := -d + kfStart; k <= d-kfEnd; k += 2 {
+			i := offset + k
+			var x int
+			if k == -d || k != d && vf[i-1] < vf[i+1] {
+				x = vf[i+1]
+			} else {
+				x = vf[i-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x, y = x+1, y+1
+			}
+			vf[i] = x
+			switch {
+			case x > n:
+				kfEnd += 2
+			case y > m:
+				kfStart += 2
+			case front:
+				if j := offset + delta - k; j >= 0 && j < len(vb) && vb[j] != -1 && x >= n-vb[j] {
+					return x, y
+				}
+			}
+		}
+
+		for k := -d + kbStart; k <= d-kbEnd; k += 2 {
+			i := offset + k
+			var x int
+			if k == -d || k != d && vb[i-1] < vb[i+1] {
+				x = vb[i+1]
+			} else {
+				x = vb[i-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[n-1-x] == b[m-1-y] {
+				x, y = x+1, y+1
+			}
+			vb[i] = x
+			switch {
+			case x > n:
+				kbEnd += 2
+			case y > m:
+				kbStart += 2
+			case !front:
+				if j := offset + delta - k; j >= 0 && j < len(vf) && vf[j] != -1 {
+					fx := vf[j]
+					if fx >= n-x {
+						return fx, fx - (j - offset)
+					}
+				}
+			}
+		}
+	}
+	return -1, -1
+}
+
+// UnifiedDiff returns the line-based differences between self and other in
+// the unified format used by diff -u and git, with context unchanged lines
+// around every change. oldLabel and newLabel are written in the "---" and
+// "+++" headers. If the texts are equal, an empty String is returned.
+func (self String) UnifiedDiff(other string, context int, oldLabel, newLabel string) String {
+	edits := self.Diff(other).Items
+	var buf strings.Builder
+
+	for _, hunk := range diffHunks(edits, max(context, 0)) {
+		if buf.Len() == 0 {
+			fmt.Fprintf(&buf, "--- %s\n+++ %s\n", oldLabel, newLabel)
+		}
+		first := hunk[0]
+		oldCount, newCount := 0, 0
+		for _, e := range hunk {
+			if e.Op != DiffInsert {
+				oldCount++
+			}
+			if e.Op != DiffDelete {
+				newCount++
+			}
+		}
+		fmt.Fprintf(&buf, "@@ -%s +%s @@\n",
+			hunkRange(first.OldIndex, oldCount), hunkRange(first.NewIndex, newCount))
+
+		for _, e := range hunk {
+			buf.WriteString(e.Op.String())
+			buf.WriteString(e.Text.Value())
+			if !e.Text.HasSuffix("\n") {
+				buf.WriteString("\n\\ No newline at end of file\n")
+			}
+		}
+	}
+	return New(buf.String())
+}
+
+// hunkRange formats the one-based line range of a unified diff hunk header
+func hunkRange(start, count int) string {
+	switch count {
+	case 0:
+		return fmt.Sprintf("%d,0", start)
+	case 1:
+		return strconv.Itoa(start + 1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
+
+// diffHunks groups edits into hunks of changes surrounded by up to context
+// equal lines. Changes separated by at most 2*context equal lines share a hunk.
+func diffHunks(edits []DiffEdit, context int) [][]DiffEdit {
+	var hunks [][]DiffEdit
+	i := 0
+	for i < len(edits) {
+		for i < len(edits) && edits[i].Op == DiffEqual {
+			i++
+		}
+		if i == len(edits) {
+			break
+		}
+		start := max(i-context, 0)
+
+		end := i
+		for end < len(edits) {
+			if edits[end].Op != DiffEqual {
+				end++
+				continue
+			}
+			run := end
+			for run < len(edits) && edits[run].Op == DiffEqual {
+				run++
+			}
+			if run == len(edits) || run-end > 2*context {
+				end = min(end+context, len(edits))
+				break
+			}
+			end = run
+		}
+		hunks = append(hunks, edits[start:end])
+		i = end
+	}
+	return hunks
+}
+
+// SideBySide returns the line-based differences between self and other as
+// two columns fitting in width characters, in the style of sdiff. The gutter
+// between the columns marks changed lines with '|', deleted lines with '<'
+// and inserted lines with '>'. Lines longer than a column are truncated.
+func (self String) SideBySide(other string, width int) String {
+	column := max((width-3)/2, 1)
+	edits := self.Diff(other).Items
+	var buf strings.Builder
+
+	row := func(left, marker, right string) {
+		buf.WriteString(fitColumn(left, column))
+		buf.WriteString(" " + marker + " ")
+		buf.WriteString(strings.TrimRight(fitColumn(right, column), " "))
+		buf.WriteString("\n")
+	}
+
+	for i := 0; i < len(edits); {
+		if edits[i].Op == DiffEqual {
+			row(edits[i].Text.Value(), " ", edits[i].Text.Value())
+			i++
+			continue
+		}
+		// Pair a block of deletions with the insertions following it
+		var deleted, inserted []string
+		for i < len(edits) && edits[i].Op == DiffDelete {
+			deleted = append(deleted, edits[i].Text.Value())
+			i++
+		}
+		for i < len(edits) && edits[i].Op == DiffInsert {
+			inserted = append(inserted, edits[i].Text.Value())
+			i++
+		}
+		for j := range max(len(deleted), len(inserted)) {
+			switch {
+			case j < len(deleted) && j < len(inserted):
+				row(deleted[j], "|", inserted[j])
+			case j < len(deleted):
+				row(deleted[j], "<", "")
+			default:
+				row("", ">", inserted[j])
+			}
+		}
+	}
+	return New(buf.String())
+}
+
+// fitColumn strips the line terminator from line, expands tabs and pads or
+// truncates the result to exactly width characters
+func fitColumn(line string, width int) string {
+	line = strings.TrimRight(line, "\r\n")
+	line = strings.ReplaceAll(line, "\t", "    ")
+	if n := utf8.RuneCountInString(line); n < width {
+		return line + strings.Repeat(" ", width-n)
+	}
+	runes := []rune(line)
+	return string(runes[:width])
+}
+
+// InlineDiff returns other merged with self at the given granularity, with
+// deleted text wrapped in "[-" and "-]" and inserted text wrapped in "{+"
+// and "+}", like git diff --word-diff=plain.
+func (self String) InlineDiff(other string, granularity DiffGranularity) String {
+	var buf strings.Builder
+	var open DiffOp = DiffEqual
+	closeRun := func() {
+		switch open {
+		case DiffDelete:
+			buf.WriteString("-]")
+		case DiffInsert:
+			buf.WriteString("+}")
+		}
+	}
+	for e := range self.DiffBy(other, granularity).Values() {
+		if e.Op != open {
+			closeRun()
+			switch e.Op {
+			case DiffDelete:
+				buf.WriteString("[-")
+			case DiffInsert:
+				buf.WriteString("{+")
+			}
+			open = e.Op
+		}
+		buf.WriteString(e.Text.Value())
+	}
+	closeRun()
+	return New(buf.String())
+}
+
+// PatchError is returned by [String.ApplyPatch] when a patch is malformed or
+// does not apply. Line is the one-based line of the patch at fault.
+type PatchError struct {
+	Line   int
+	Reason string
+}
+
+// Error implements the error interface
+func (e *PatchError) Error() string {
+	return fmt.Sprintf("patch line %d: %s", e.Line, e.Reason)
+}
+
+// ApplyPatch applies the unified diff patch, such as one produced by
+// [String.UnifiedDiff], to self and returns the patched text. Every context
+// and deleted line of the patch must match self exactly, otherwise a
+// *[PatchError] is returned.
+func (self String) ApplyPatch(patch string) (String, error) {
+	var old []string
+	for line := range self.Lines() {
+		old = append(old, line.Value())
+	}
+
+	var out strings.Builder
+	pos := 0                          // next line of old to copy
+	haveLast, lastNew := false, false // previous hunk line, for "\ No newline" markers
+	var oldLeft, newLeft int
+	lineNo := 0
+
+	for line := range strings.Lines(patch) {
+		lineNo++
+		if oldLeft == 0 && newLeft == 0 {
+			if strings.HasPrefix(line, `\`) && haveLast {
+				trimLastNewline(&out, lastNew)
+				haveLast = false
+				continue
+			}
+			if !strings.HasPrefix(line, "@@") {
+				// Headers and trailing garbage between hunks are ignored
+				continue
+			}
+			oldStart, oldCount, newCount, err := parseHunkHeader(line)
+			if err != nil {
+				return "", &PatchError{lineNo, err.Error()}
+			}
+			if oldCount == 0 {
+				oldStart++ // an empty range names the line before it
+			}
+			if oldStart-1 < pos || oldStart-1 > len(old) {
+				return "", &PatchError{lineNo, "hunk out of range"}
+			}
+			for ; pos < oldStart-1; pos++ {
+				out.WriteString(old[pos])
+			}
+			oldLeft, newLeft = oldCount, newCount
+			haveLast = false
+			continue
+		}
+
+		if strings.HasPrefix(line, `\`) {
+			if !haveLast {
+				return "", &PatchError{lineNo, "unexpected no-newline marker"}
+			}
+			trimLastNewline(&out, lastNew)
+			haveLast = false
+			continue
+		}
+
+		op, text := byte(' '), line
+		if line != "\n" {
+			op, text = line[0], line[1:]
+		}
+		switch op {
+		case ' ', '-':
+			if oldLeft == 0 || pos >= len(old) || strings.TrimSuffix(old[pos], "\n") != strings.TrimSuffix(text, "\n") {
+				return "", &PatchError{lineNo, fmt.Sprintf("does not match line %d", pos+1)}
+			}
+			if op == ' ' {
+				if newLeft == 0 {
+					return "", &PatchError{lineNo, "hunk longer than its header"}
+				}
+				out.WriteString(old[pos])
+				newLeft--
+			}
+			pos++
+			oldLeft--
+			lastNew = op == ' '
+		case '+':
+			if newLeft == 0 {
+				return "", &PatchError{lineNo, "hunk longer than its header"}
+			}
+			out.WriteString(text)
+			newLeft--
+			lastNew = true
+		default:
+			return "", &PatchError{lineNo, fmt.Sprintf("unexpected line prefix %q", op)}
+		}
+		haveLast = true
+	}
+
+	if oldLeft != 0 || newLeft != 0 {
+		return "", &PatchError{lineNo, "unexpected end of patch"}
+	}
+	for ; pos < len(old); pos++ {
+		out.WriteString(old[pos])
+	}
+	return New(out.String()), nil
+}
+
+// trimLastNewline removes the newline written for the previous hunk line if
+// that line was part of the new text
+func trimLastNewline(out *strings.Builder, lastNew bool) {
+	if !lastNew {
+		return
+	}
+	s := out.String()
+	if strings.HasSuffix(s, "\n") {
+		out.Reset()
+		out.WriteString(s[:len(s)-1])
+	}
+}
+
+// parseHunkHeader parses a "@@ -l,s +l,s @@" line
+func parseHunkHeader(line string) (oldStart, oldCount, newCount int, err error) {
+	fields := strings.Fields(line)
+	if len(fields) < 4 || fields[0] != "@@" || fields[3] != "@@" ||
+		!strings.HasPrefix(fields[1], "-") || !strings.HasPrefix(fields[2], "+") {
+		return 0, 0, 0, fmt.Errorf("malformed hunk header %q", strings.TrimSpace(line))
+	}
+	oldStart, oldCount, err = parseHunkRange(fields[1][1:])
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	_, newCount, err = parseHunkRange(fields[2][1:])
+	return oldStart, oldCount, newCount, err
+}
+
+// parseHunkRange parses the "start,count" or "start" range of a hunk header
+func parseHunkRange(s string) (start, count int, err error) {
+	startText, countText, found := strings.Cut(s, ",")
+	if start, err = strconv.Atoi(startText); err != nil {
+		return 0, 0, fmt.Errorf("malformed hunk range %q", s)
+	}
+	if !found {
+		return start, 1, nil
+	}
+	if count, err = strconv.Atoi(countText); err != nil {
+		return 0, 0, fmt.Errorf("malformed hunk range %q", s)
+	}
+	return start, count, nil
+}
@@ -0,0 +1,142 @@
+package String
+
+import (
+	"fmt"
+	"math/rand"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// randomLines returns n lines drawn from a small alphabet, so that texts
+// share many lines, optionally without a final newline
+func randomLines(rng *rand.Rand, n int) string {
+	var buf strings.Builder
+	for range n {
+		fmt.Fprintf(&buf, "line %c\n", 'a'+rng.Intn(5))
+	}
+	s := buf.String()
+	if s != "" && rng.Intn(4) == 0 {
+		s = strings.TrimSuffix(s, "\n")
+	}
+	return s
+}
+
+// lcsLength returns the length of the longest common subsequence of a and b
+func lcsLength(a, b []string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for i := range a {
+		for j := range b {
+			if a[i] == b[j] {
+				cur[j+1] = prev[j] + 1
+			} else {
+				cur[j+1] = max(cur[j], prev[j+1])
+			}
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+func TestDiffIsShortest(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for range 500 {
+		old, new := randomLines(rng, rng.Intn(30)), randomLines(rng, rng.Intn(30))
+		a, b := diffTokens(old, DiffLines), diffTokens(new, DiffLines)
+		edits := New(old).Diff(new).Items
+
+		var rebuiltOld, rebuiltNew strings.Builder
+		changes := 0
+		for _, e := range edits {
+			if e.Op != DiffInsert {
+				if a[e.OldIndex] != e.Text.Value() {
+					t.Fatalf("edit %v has the wrong OldIndex", e)
+				}
+				rebuiltOld.WriteString(e.Text.Value())
+			}
+			if e.Op != DiffDelete {
+				if b[e.NewIndex] != e.Text.Value() {
+					t.Fatalf("edit %v has the wrong NewIndex", e)
+				}
+				rebuiltNew.WriteString(e.Text.Value())
+			}
+			if e.Op != DiffEqual {
+				changes++
+			}
+		}
+		if rebuiltOld.String() != old || rebuiltNew.String() != new {
+			t.Fatalf("edits of %q -> %q do not rebuild the texts", old, new)
+		}
+		if want := len(a) + len(b) - 2*lcsLength(a, b); changes != want {
+			t.Fatalf("diff of %q -> %q has %d changes, want %d", old, new, changes, want)
+		}
+	}
+}
+
+func TestUnifiedDiffRoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	for range 500 {
+		old, new := randomLines(rng, rng.Intn(40)), randomLines(rng, rng.Intn(40))
+		for _, context := range []int{0, 1, 3} {
+			patch := New(old).UnifiedDiff(new, context, "a", "b")
+			got, err := New(old).ApplyPatch(patch.Value())
+			if err != nil {
+				t.Fatalf("ApplyPatch(%q) to %q: %v", patch, old, err)
+			}
+			if got.Value() != new {
+				t.Fatalf("ApplyPatch(%q) to %q = %q, want %q", patch, old, got, new)
+			}
+		}
+	}
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	got := New("a\nb\nc\n").UnifiedDiff("a\nB\nc\n", 1, "old", "new")
+	want := "--- old\n+++ new\n@@ -1,3 +1,3 @@\n a\n-b\n+B\n c\n"
+	if got.Value() != want {
+		t.Errorf("UnifiedDiff = %q, want %q", got, want)
+	}
+	if got := New("same\n").UnifiedDiff("same\n", 3, "a", "b"); got != "" {
+		t.Errorf("UnifiedDiff of equal texts = %q, want empty", got)
+	}
+}
+
+func TestApplyPatchMismatch(t *testing.T) {
+	patch := New("a\nb\n").UnifiedDiff("a\nc\n", 1, "x", "y")
+	_, err := New("a\nz\n").ApplyPatch(patch.Value())
+	if _, ok := err.(*PatchError); !ok {
+		t.Errorf("ApplyPatch to a different text: err = %v, want a *PatchError", err)
+	}
+}
+
+func TestInlineDiff(t *testing.T) {
+	got := New("the quick fox").InlineDiff("the slow fox", DiffWords)
+	if want := "the [-quick-]{+slow+} fox"; got.Value() != want {
+		t.Errorf("InlineDiff = %q, want %q", got, want)
+	}
+}
+
+func TestDiffLargeRewrite(t *testing.T) {
+	// A complete rewrite is the worst case for memory, which must grow about
+	// linearly with the number of lines rather than with its square
+	allocated := func(lines int) uint64 {
+		var old, new strings.Builder
+		for i := range lines {
+			fmt.Fprintf(&old, "old %d\n", i)
+			fmt.Fprintf(&new, "new %d\n", i)
+		}
+		a, b := New(old.String()), new.String()
+		var before, after runtime.MemStats
+		runtime.ReadMemStats(&before)
+		if n := a.Diff(b).Length(); n != 2*lines {
+			t.Fatalf("diff has %d edits, want %d", n, 2*lines)
+		}
+		runtime.ReadMemStats(&after)
+		return after.TotalAlloc - before.TotalAlloc
+	}
+	small, large := allocated(1000), allocated(4000)
+	if large > 8*small {
+		t.Errorf("4 times more lines made the diff allocate %.1f times more", float64(large)/float64(small))
+	}
+}
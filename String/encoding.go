@@ -0,0 +1,284 @@
+package String
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Encoding is a character encoding which text can be decoded from with
+// [DecodeFrom] and encoded to with [String.EncodeTo]. The supported
+// encodings are the variables of this type, such as [UTF16] and
+// [Windows1252]; use [EncodingByName] to look one up by a label.
+type Encoding struct {
+	name    string
+	charmap *charmap // for single-byte encodings
+	utf16   byte     // 0 for other encodings, or 'L', 'B' or '?' for UTF-16 with a BOM
+	encode  map[rune]byte
+	once    sync.Once
+}
+
+// charmap gives the characters of the bytes from 0x80 to 0xFF of a
+// single-byte encoding; the bytes below are ASCII
+type charmap [128]rune
+
+// The supported encodings
+var (
+	// UTF8 is UTF-8. Decoding removes a byte order mark and replaces invalid
+	// bytes with U+FFFD.
+	UTF8 = &Encoding{name: "UTF-8"}
+	// UTF16 is UTF-16 with a byte order mark. Decoding follows the byte
+	// order mark, assuming little-endian without one as Windows writes it,
+	// and encoding writes a byte order mark followed by little-endian text.
+	UTF16 = &Encoding{name: "UTF-16", utf16: '?'}
+	// UTF16LE and UTF16BE are UTF-16 in a fixed byte order. Decoding removes
+	// a byte order mark in that order, and encoding does not write one.
+	UTF16LE = &Encoding{name: "UTF-16LE", utf16: 'L'}
+	UTF16BE = &Encoding{name: "UTF-16BE", utf16: 'B'}
+
+	ISO8859_1   = &Encoding{name: "ISO-8859-1", charmap: latin1Table()}       // Latin-1, Western European
+	ISO8859_15  = &Encoding{name: "ISO-8859-15", charmap: &iso885915Table}    // Latin-9, Latin-1 with €
+	Windows1250 = &Encoding{name: "Windows-1250", charmap: &windows1250Table} // Central European
+	Windows1251 = &Encoding{name: "Windows-1251", charmap: &windows1251Table} // Cyrillic
+	Windows1252 = &Encoding{name: "Windows-1252", charmap: &windows1252Table} // Western European
+	KOI8R       = &Encoding{name: "KOI8-R", charmap: &koi8rTable}             // Russian
+	CodePage437 = &Encoding{name: "IBM437", charmap: &codePage437Table}       // the original IBM PC character set
+)
+
+// latin1Table returns the table of ISO-8859-1, whose bytes are the first
+// 256 Unicode code points
+func latin1Table() *charmap {
+	var table charmap
+	for i := range table {
+		table[i] = rune(0x80 + i)
+	}
+	return &table
+}
+
+// encodingLabels maps lower-cased labels to encodings
+var encodingLabels = map[string]*Encoding{
+	"utf-8": UTF8, "utf8": UTF8,
+	"utf-16": UTF16, "utf16": UTF16,
+	"utf-16le": UTF16LE, "utf16le": UTF16LE,
+	"utf-16be": UTF16BE, "utf16be": UTF16BE,
+	"iso-8859-1": ISO8859_1, "iso8859-1": ISO8859_1, "latin1": ISO8859_1, "latin-1": ISO8859_1, "l1": ISO8859_1,
+	"iso-8859-15": ISO8859_15, "iso8859-15": ISO8859_15, "latin9": ISO8859_15, "latin-9": ISO8859_15,
+	"windows-1250": Windows1250, "cp1250": Windows1250,
+	"windows-1251": Windows1251, "cp1251": Windows1251,
+	"windows-1252": Windows1252, "cp1252": Windows1252,
+	"koi8-r": KOI8R, "koi8r": KOI8R,
+	"ibm437": CodePage437, "cp437": CodePage437, "437": CodePage437,
+}
+
+// EncodingByName returns the encoding with the given name or label, such as
+// "UTF-16LE", "latin1" or "cp1252", ignoring case, and whether it was found
+func EncodingByName(name string) (*Encoding, bool) {
+	enc, ok := encodingLabels[strings.ToLower(strings.TrimSpace(name))]
+	return enc, ok
+}
+
+// Name returns the name of the encoding, such as "Windows-1252"
+func (enc *Encoding) Name() string {
+	return enc.name
+}
+
+// String implements [fmt.Stringer]
+func (enc *Encoding) String() string {
+	return enc.name
+}
+
+// EncodeError is returned by [String.EncodeTo] when a character cannot be
+// represented in the target encoding
+type EncodeError struct {
+	Encoding string // name of the target encoding
+	Rune     rune   // the character which cannot be encoded
+	Offset   int    // byte offset of the character in the String
+}
+
+// Error implements the error interface
+func (e *EncodeError) Error() string {
+	return fmt.Sprintf("String.EncodeTo: %U %q at offset %d cannot be encoded in %s", e.Rune, e.Rune, e.Offset, e.Encoding)
+}
+
+// DecodeFrom decodes data from the encoding enc into a String. Bytes which
+// have no character in enc, invalid UTF-8 and unpaired UTF-16 surrogates
+// decode to U+FFFD, so decoding does not fail.
+func DecodeFrom(enc *Encoding, data []byte) String {
+	switch {
+	case enc.charmap != nil:
+		var buf strings.Builder
+		buf.Grow(len(data))
+		for _, b := range data {
+			if b < utf8.RuneSelf {
+				buf.WriteByte(b)
+			} else {
+				buf.WriteRune(enc.charmap[b-0x80])
+			}
+		}
+		return New(buf.String())
+	case enc.utf16 != 0:
+		return decodeUTF16(enc.utf16, data)
+	}
+	data = bytes.TrimPrefix(data, []byte("\xEF\xBB\xBF"))
+	return New(strings.ToValidUTF8(string(data), "�"))
+}
+
+// decodeUTF16 decodes UTF-16 text in the byte order order
+func decodeUTF16(order byte, data []byte) String {
+	switch {
+	case order != 'B' && bytes.HasPrefix(data, []byte{0xFF, 0xFE}):
+		order, data = 'L', data[2:]
+	case order != 'L' && bytes.HasPrefix(data, []byte{0xFE, 0xFF}):
+		order, data = 'B', data[2:]
+	case order == '?':
+		order = 'L'
+	}
+
+	units := make([]uint16, len(data)/2)
+	for i := range units {
+		lo, hi := data[2*i], data[2*i+1]
+		if order == 'B' {
+			lo, hi = hi, lo
+		}
+		units[i] = uint16(lo) | uint16(hi)<<8
+	}
+	runes := utf16.Decode(units)
+	if len(data)%2 != 0 {
+		// A truncated final code unit
+		runes = append(runes, utf8.RuneError)
+	}
+	return New(string(runes))
+}
+
+// EncodeTo encodes self in the encoding enc. It returns an *[EncodeError]
+// for the first character which enc cannot represent. Invalid UTF-8 in self
+// is read as U+FFFD, which is encoded as such in UTF-8 and UTF-16; the
+// single-byte encodings have no U+FFFD, so there it is an EncodeError.
+func (self String) EncodeTo(enc *Encoding) ([]byte, error) {
+	s := self.Value()
+	switch {
+	case enc.charmap != nil:
+		enc.once.Do(enc.buildEncodeTable)
+		out := make([]byte, 0, len(s))
+		for i, r := range s {
+			if r < utf8.RuneSelf {
+				out = append(out, byte(r))
+				continue
+			}
+			b, ok := enc.encode[r]
+			if !ok {
+				return nil, &EncodeError{Encoding: enc.name, Rune: r, Offset: i}
+			}
+			out = append(out, b)
+		}
+		return out, nil
+	case enc.utf16 != 0:
+		out := make([]byte, 0, 2*len(s)+2)
+		if enc.utf16 == '?' {
+			out = append(out, 0xFF, 0xFE)
+		}
+		for _, unit := range utf16.Encode([]rune(s)) {
+			if enc.utf16 == 'B' {
+				out = append(out, byte(unit>>8), byte(unit))
+			} else {
+				out = append(out, byte(unit), byte(unit>>8))
+			}
+		}
+		return out, nil
+	}
+	return []byte(strings.ToValidUTF8(s, "�")), nil
+}
+
+// buildEncodeTable builds the table mapping characters to bytes of a
+// single-byte encoding
+func (enc *Encoding) buildEncodeTable() {
+	enc.encode = make(map[rune]byte, len(enc.charmap))
+	for i, r := range enc.charmap {
+		if r != utf8.RuneError {
+			enc.encode[r] = byte(0x80 + i)
+		}
+	}
+}
+
+// SniffEncoding guesses the encoding of data among the supported ones. It
+// recognises byte order marks, UTF-16 without one from the repeated high
+// bytes of its code units, and valid UTF-8; other text is taken to be Cyrillic in
+// Windows-1251 or KOI8-R if most of its letters are outside ASCII, and
+// Windows-1252 otherwise. The guess is only reliable for text of a few
+// dozen characters or more.
+func SniffEncoding(data []byte) *Encoding {
+	switch {
+	case bytes.HasPrefix(data, []byte("\xEF\xBB\xBF")):
+		return UTF8
+	case bytes.HasPrefix(data, []byte{0xFF, 0xFE}):
+		return UTF16LE
+	case bytes.HasPrefix(data, []byte{0xFE, 0xFF}):
+		return UTF16BE
+	}
+
+	// In UTF-16 text without a BOM, the high bytes of most code units are
+	// zero for ASCII or the block of the script, so one side of each pair of
+	// bytes is dominated by one or two values
+	sample := data[:min(len(data), 4096)&^1]
+	if units := len(sample) / 2; units > 0 {
+		var even, odd [256]int
+		for i := 0; i < len(sample); i += 2 {
+			even[sample[i]]++
+			odd[sample[i+1]]++
+		}
+		evenTop, oddTop := topTwo(even), topTwo(odd)
+		switch {
+		case oddTop*10 >= units*8 && evenTop*10 < units*4:
+			return UTF16LE
+		case evenTop*10 >= units*8 && oddTop*10 < units*4:
+			return UTF16BE
+		}
+	}
+
+	if utf8.Valid(data) {
+		return UTF8
+	}
+
+	// Cyrillic text is mostly made of letters outside ASCII, while Western
+	// European text has a few accented letters among ASCII ones
+	asciiLetters, high := 0, 0
+	for _, b := range data {
+		switch {
+		case b >= 0x80:
+			high++
+		case 'a' <= b && b <= 'z' || 'A' <= b && b <= 'Z':
+			asciiLetters++
+		}
+	}
+	if high > asciiLetters {
+		// Most Cyrillic letters are lower case, which are in the upper half
+		// of the Cyrillic range in Windows-1251 and the lower in KOI8-R
+		if countLower(DecodeFrom(KOI8R, data)) > countLower(DecodeFrom(Windows1251, data)) {
+			return KOI8R
+		}
+		return Windows1251
+	}
+	return Windows1252
+}
+
+// topTwo returns the sum of the two largest counts
+func topTwo(counts [256]int) int {
+	slices.Sort(counts[:])
+	return counts[255] + counts[254]
+}
+
+// countLower returns the number of lower case letters in s
+func countLower(s String) int {
+	n := 0
+	for _, r := range s.Value() {
+		if unicode.IsLower(r) {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,86 @@
+package String
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestEncodingRoundTrip(t *testing.T) {
+	tests := []struct {
+		enc  *Encoding
+		text String
+	}{
+		{UTF8, "héllo wörld €"},
+		{UTF16, "héllo 😀"},
+		{UTF16LE, "héllo 😀"},
+		{UTF16BE, "héllo 😀"},
+		{ISO8859_1, "café déjà vu"},
+		{ISO8859_15, "prix 5 €"},
+		{Windows1250, "Zażółć gęślą jaźń"},
+		{Windows1251, "Привет, мир"},
+		{Windows1252, "“quoted” – café €"},
+		{KOI8R, "Привет, мир"},
+		{CodePage437, "┌─┐ ░▒▓ café"},
+	}
+	for _, tt := range tests {
+		data, err := tt.text.EncodeTo(tt.enc)
+		if err != nil {
+			t.Errorf("EncodeTo(%s) of %q: %v", tt.enc, tt.text, err)
+			continue
+		}
+		if got := DecodeFrom(tt.enc, data); got != tt.text {
+			t.Errorf("%s round trip of %q = %q", tt.enc, tt.text, got)
+		}
+	}
+}
+
+func TestEncodeError(t *testing.T) {
+	_, err := New("ok 日本").EncodeTo(Windows1252)
+	var ee *EncodeError
+	if !errors.As(err, &ee) || ee.Rune != '日' || ee.Offset != 3 {
+		t.Errorf("EncodeTo(Windows1252) error = %v", err)
+	}
+
+	// Invalid UTF-8 is read as U+FFFD, which only the Unicode encodings have
+	invalid := New("a\xffb")
+	if data, err := invalid.EncodeTo(UTF8); err != nil || string(data) != "a�b" {
+		t.Errorf("EncodeTo(UTF8) of invalid UTF-8 = %q, %v", data, err)
+	}
+	if _, err := invalid.EncodeTo(ISO8859_1); !errors.As(err, &ee) || ee.Rune != '�' || ee.Offset != 1 {
+		t.Errorf("EncodeTo(ISO8859_1) of invalid UTF-8 error = %v", err)
+	}
+}
+
+func TestSniffEncoding(t *testing.T) {
+	encode := func(s string, enc *Encoding) []byte {
+		data, err := New(s).EncodeTo(enc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return data
+	}
+	russian := "Съешь же ещё этих мягких французских булок, да выпей чаю. "
+	tests := []struct {
+		data []byte
+		want *Encoding
+	}{
+		{append([]byte("\xEF\xBB\xBF"), "text"...), UTF8},
+		{encode("hello there, this is some text", UTF16LE), UTF16LE},
+		{encode("hello there, this is some text", UTF16BE), UTF16BE},
+		{encode(russian, UTF16LE), UTF16LE},
+		{[]byte("plain ascii text"), UTF8},
+		{[]byte("héllo wörld"), UTF8},
+		{encode("Le café est très bon, merci beaucoup.", Windows1252), Windows1252},
+		{encode(russian, Windows1251), Windows1251},
+		{encode(russian, KOI8R), KOI8R},
+	}
+	for _, tt := range tests {
+		if got := SniffEncoding(tt.data); got != tt.want {
+			t.Errorf("SniffEncoding(% x...) = %s, want %s", tt.data[:min(len(tt.data), 8)], got, tt.want)
+		}
+	}
+	if !bytes.Equal(encode("a", UTF16), []byte{0xFF, 0xFE, 'a', 0}) {
+		t.Errorf("UTF16 does not write a little-endian BOM")
+	}
+}
@@ -0,0 +1,248 @@
+package String
+
+import (
+	"net/netip"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/harishtpj/klassy/Slice"
+)
+
+// Span is a piece of text found in a String together with its position
+type Span struct {
+	Text  String
+	Start int // byte offset of the first byte of Text
+	End   int // byte offset just after Text
+}
+
+// Patterns used by the Extract methods. They are deliberately permissive;
+// candidates are then checked or trimmed by the corresponding method.
+var (
+	emailCandidate   = regexp.MustCompile(`[A-Za-z0-9.!#$%&'*+/=?^_{|}~-]+@[A-Za-z0-9](?:[A-Za-z0-9-]*[A-Za-z0-9])?(?:\.[A-Za-z0-9](?:[A-Za-z0-9-]*[A-Za-z0-9])?)+`)
+	urlCandidate     = regexp.MustCompile(`(?i)\b(?:https?://|ftp://|www\.)[^\s<>"]+`)
+	ipv4Candidate    = regexp.MustCompile(`\d{1,3}(?:\.\d{1,3}){3}`)
+	ipv6Candidate    = regexp.MustCompile(`[0-9A-Fa-f]*:[0-9A-Fa-f:.]*[0-9A-Fa-f:](?:%[0-9A-Za-z_.]+)?`)
+	numberCandidate  = regexp.MustCompile(`[+-]?(?:\d{1,3}(?:,\d{3})+|\d+)(?:\.\d+)?(?:[eE][+-]?\d+)?`)
+	mentionCandidate = regexp.MustCompile(`@[\p{L}\p{N}_]+(?:[.-][\p{L}\p{N}_]+)*`)
+	hashtagCandidate = regexp.MustCompile(`#[\p{L}\p{N}_]*\p{L}[\p{L}\p{N}_]*`)
+	dateCandidate    = regexp.MustCompile(`\b(?:\d{4}-\d{2}-\d{2}(?:[T ]\d{2}:\d{2}(?::\d{2}(?:\.\d+)?)?(?:Z|[+-]\d{2}:?\d{2})?)?|\d{1,2}/\d{1,2}/\d{4}|\d{1,2} (?:Jan|Feb|Mar|Apr|May|Jun|Jul|Aug|Sep|Oct|Nov|Dec)[a-z]* \d{4}|(?:Jan|Feb|Mar|Apr|May|Jun|Jul|Aug|Sep|Oct|Nov|Dec)[a-z]* \d{1,2},? \d{4})\b`)
+)
+
+// spansOf returns the non-overlapping matches of re in self which keep
+// returns true for, after adjusting them with keep
+func (self String) spansOf(re *regexp.Regexp, keep func(s string, start, end int) (int, int, bool)) Slice.Slice[Span] {
+	s := self.Value()
+	spans := Slice.New([]Span{})
+	for _, loc := range re.FindAllStringIndex(s, -1) {
+		start, end, ok := loc[0], loc[1], true
+		if keep != nil {
+			start, end, ok = keep(s, start, end)
+		}
+		if ok && start < end {
+			spans.Push(Span{Text: New(s[start:end]), Start: start, End: end})
+		}
+	}
+	return spans
+}
+
+// spanTexts returns the texts of spans
+func spanTexts(spans Slice.Slice[Span]) Slice.Slice[String] {
+	return Slice.MapTo(spans, func(sp Span) String { return sp.Text })
+}
+
+// precededByWord reports whether the byte before start in s is part of a
+// word, so a match there is in the middle of a longer token
+func precededByWord(s string, start int) bool {
+	return start > 0 && (isAlnumASCII(s[start-1]) || s[start-1] == '_')
+}
+
+// EmailSpans returns the e-mail addresses in self with their positions.
+func (self String) EmailSpans() Slice.Slice[Span] {
+	return self.spansOf(emailCandidate, func(s string, start, end int) (int, int, bool) {
+		// Sentence punctuation is not part of the local part
+		for start < end && strings.IndexByte(".'", s[start]) >= 0 {
+			start++
+		}
+		return start, end, New(s[start:end]).IsEmail()
+	})
+}
+
+// ExtractEmails returns the e-mail addresses in self.
+func (self String) ExtractEmails() Slice.Slice[String] {
+	return spanTexts(self.EmailSpans())
+}
+
+// URLSpans returns the URLs in self with their positions. URLs start with
+// "http://", "https://", "ftp://" or "www.". As in chat clients, trailing
+// punctuation such as a final period or comma is left out, and a closing
+// parenthesis is only kept if the URL contains the matching opening one, so
+// "(see https://en.wikipedia.org/wiki/Go_(programming_language))" gives the
+// full article address.
+func (self String) URLSpans() Slice.Slice[Span] {
+	return self.spansOf(urlCandidate, func(s string, start, end int) (int, int, bool) {
+		if precededByWord(s, start) {
+			return start, end, false
+		}
+		return start, trimURLEnd(s, start, end), true
+	})
+}
+
+// trimURLEnd returns the end of the URL s[start:end] without trailing
+// punctuation and unbalanced closing brackets
+func trimURLEnd(s string, start, end int) int {
+	pairs := map[byte]byte{')': '(', ']': '[', '}': '{'}
+	for end > start {
+		c := s[end-1]
+		if strings.IndexByte(".,:;!?'\"*", c) >= 0 {
+			end--
+			continue
+		}
+		if open, ok := pairs[c]; ok {
+			url := s[start:end]
+			if strings.Count(url, string(open)) < strings.Count(url, string(c)) {
+				end--
+				continue
+			}
+		}
+		break
+	}
+	return end
+}
+
+// ExtractURLs returns the URLs in self, see [String.URLSpans].
+func (self String) ExtractURLs() Slice.Slice[String] {
+	return spanTexts(self.URLSpans())
+}
+
+// IPSpans returns the IPv4 and IPv6 addresses in self with their
+// positions. IPv6 addresses may carry a zone, as in "fe80::1%eth0".
+func (self String) IPSpans() Slice.Slice[Span] {
+	valid := func(s string, start, end int) (int, int, bool) {
+		// A zone does not end in sentence punctuation
+		for end > start && s[end-1] == '.' {
+			end--
+		}
+		// A port, as in "10.0.0.1:8080", follows the address but is not part of it
+		after := end
+		if after+1 < len(s) && s[after] == ':' && isDigitByte(s[after+1]) {
+			for after++; after < len(s) && isDigitByte(s[after]); after++ {
+			}
+		}
+		if isInToken(s, start, after) {
+			return start, end, false
+		}
+		_, err := netip.ParseAddr(s[start:end])
+		return start, end, err == nil
+	}
+	spans := self.spansOf(ipv4Candidate, valid)
+	spans.Concat(self.spansOf(ipv6Candidate, valid).Items)
+	spans.SortFunc(func(a, b Span) int { return a.Start - b.Start })
+	return spans
+}
+
+// isDigitByte reports whether c is an ASCII digit
+func isDigitByte(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+// ExtractIPs returns the IPv4 and IPv6 addresses in self.
+func (self String) ExtractIPs() Slice.Slice[String] {
+	return spanTexts(self.IPSpans())
+}
+
+// NumberSpans returns the numbers in self with their positions: integers,
+// decimals, numbers with thousands separators such as "1,234,567" and
+// numbers in scientific notation, with an optional sign. Digits within
+// larger tokens such as "mp3", "2024-03-01" or "10.0.0.1" are left out.
+func (self String) NumberSpans() Slice.Slice[Span] {
+	return self.spansOf(numberCandidate, func(s string, start, end int) (int, int, bool) {
+		return start, end, !isInToken(s, start, end)
+	})
+}
+
+// isInToken reports whether s[start:end] is glued to the surrounding text,
+// either directly by a letter or digit or by a separator such as '.', '-',
+// '/' or ':' followed by one
+func isInToken(s string, start, end int) bool {
+	if start > 0 {
+		c := s[start-1]
+		if isAlnumASCII(c) || c == '_' {
+			return true
+		}
+		if strings.IndexByte(".-/:", c) >= 0 && start > 1 && (isAlnumASCII(s[start-2]) || s[start-2] == ':') {
+			return true
+		}
+	}
+	if end < len(s) {
+		c := s[end]
+		if isAlnumASCII(c) || c == '_' {
+			return true
+		}
+		if strings.IndexByte(".-/:", c) >= 0 && end+1 < len(s) && isAlnumASCII(s[end+1]) {
+			return true
+		}
+	}
+	return false
+}
+
+// ExtractNumbers returns the numbers in self, see [String.NumberSpans].
+func (self String) ExtractNumbers() Slice.Slice[String] {
+	return spanTexts(self.NumberSpans())
+}
+
+// MentionSpans returns the @mentions in self, such as "@alice", with their
+// positions. The '@' of e-mail addresses does not start a mention.
+func (self String) MentionSpans() Slice.Slice[Span] {
+	return self.spansOf(mentionCandidate, func(s string, start, end int) (int, int, bool) {
+		return start, end, !precededByWord(s, start) && !(start > 0 && strings.IndexByte(".-+", s[start-1]) >= 0)
+	})
+}
+
+// ExtractMentions returns the @mentions in self, see [String.MentionSpans].
+func (self String) ExtractMentions() Slice.Slice[String] {
+	return spanTexts(self.MentionSpans())
+}
+
+// HashtagSpans returns the hashtags in self, such as "#golang", with their
+// positions. A hashtag must contain at least one letter, so "#1" is not one.
+func (self String) HashtagSpans() Slice.Slice[Span] {
+	return self.spansOf(hashtagCandidate, func(s string, start, end int) (int, int, bool) {
+		return start, end, !precededByWord(s, start) && !(start > 0 && s[start-1] == '&')
+	})
+}
+
+// ExtractHashtags returns the hashtags in self, see [String.HashtagSpans].
+func (self String) ExtractHashtags() Slice.Slice[String] {
+	return spanTexts(self.HashtagSpans())
+}
+
+// dateLayouts are the layouts used to check candidate dates
+var dateLayouts = []string{
+	time.DateOnly, "2006-01-02T15:04", "2006-01-02 15:04", time.DateTime,
+	time.RFC3339Nano, "2006-01-02T15:04:05.999999999", "2006-01-02 15:04:05Z07:00",
+	"2006-01-02T15:04Z07:00", "2006-01-02T15:04:05-0700",
+	"1/2/2006", "2 Jan 2006", "2 January 2006", "Jan 2, 2006", "January 2, 2006",
+	"Jan 2 2006", "January 2 2006",
+}
+
+// DateSpans returns the dates in self with their positions. ISO 8601 dates
+// and times such as "2024-03-01" and "2024-03-01T10:30:00Z", numeric dates
+// such as "1/3/2024" (month first) and written dates such as "1 March 2024"
+// and "March 1, 2024" are recognised. Impossible dates are left out.
+func (self String) DateSpans() Slice.Slice[Span] {
+	return self.spansOf(dateCandidate, func(s string, start, end int) (int, int, bool) {
+		candidate := s[start:end]
+		for _, layout := range dateLayouts {
+			if _, err := time.Parse(layout, candidate); err == nil {
+				return start, end, true
+			}
+		}
+		return start, end, false
+	})
+}
+
+// ExtractDates returns the dates in self, see [String.DateSpans].
+func (self String) ExtractDates() Slice.Slice[String] {
+	return spanTexts(self.DateSpans())
+}
@@ -0,0 +1,172 @@
+package String
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/harishtpj/klassy/Slice"
+)
+
+// equalFoldRune reports whether r and t are equal under simple Unicode
+// case folding, as used by strings.EqualFold
+func equalFoldRune(r, t rune) bool {
+	if r == t {
+		return true
+	}
+	if r < utf8.RuneSelf && t < utf8.RuneSelf {
+		if 'A' <= r && r <= 'Z' {
+			r += 'a' - 'A'
+		}
+		if 'A' <= t && t <= 'Z' {
+			t += 'a' - 'A'
+		}
+		return r == t
+	}
+	// Walk the orbit of r, the characters equivalent to it under folding
+	for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+		if f == t {
+			return true
+		}
+	}
+	return false
+}
+
+// prefixFold returns the length in bytes of the prefix of s which equals
+// substr under simple case folding. The length may differ from len(substr),
+// as 'K' (the Kelvin sign) folds to 'k', for example.
+func prefixFold(s, substr string) (n int, ok bool) {
+	for substr != "" {
+		if n == len(s) {
+			return 0, false
+		}
+		r, size := utf8.DecodeRuneInString(s[n:])
+		t, tsize := utf8.DecodeRuneInString(substr)
+		if !equalFoldRune(r, t) {
+			return 0, false
+		}
+		n += size
+		substr = substr[tsize:]
+	}
+	return n, true
+}
+
+// indexFold returns the byte offsets in s of the first instance of substr
+// under simple case folding at or after from, or -1, -1 if there is none
+func indexFold(s, substr string, from int) (start, end int) {
+	for i := from; i <= len(s); {
+		if n, ok := prefixFold(s[i:], substr); ok {
+			return i, i + n
+		}
+		if i == len(s) {
+			break
+		}
+		_, size := utf8.DecodeRuneInString(s[i:])
+		i += size
+	}
+	return -1, -1
+}
+
+// IndexFold returns the byte index of the first instance of substr in self
+// under simple Unicode case folding, or -1 if substr is not present in self.
+// The index refers to self, even when the matching characters and those of
+// substr have different lengths in UTF-8.
+func (self String) IndexFold(substr string) int {
+	start, _ := indexFold(self.Value(), substr, 0)
+	return start
+}
+
+// ContainsFold reports whether substr is within self under simple Unicode
+// case folding
+func (self String) ContainsFold(substr string) bool {
+	return self.IndexFold(substr) >= 0
+}
+
+// HasPrefixFold reports if self starts with prefix under simple Unicode
+// case folding
+func (self String) HasPrefixFold(prefix string) bool {
+	_, ok := prefixFold(self.Value(), prefix)
+	return ok
+}
+
+// HasSuffixFold reports if self ends with suffix under simple Unicode
+// case folding
+func (self String) HasSuffixFold(suffix string) bool {
+	s := self.Value()
+	for i := len(s); i >= 0; i-- {
+		if i < len(s) && !utf8.RuneStart(s[i]) {
+			continue
+		}
+		if n, ok := prefixFold(s[i:], suffix); ok && i+n == len(s) {
+			return true
+		}
+	}
+	return false
+}
+
+// CountFold counts the number of non-overlapping instances of substr in self
+// under simple Unicode case folding. If substr is an empty string, CountFold
+// returns 1 + the number of characters in self.
+func (self String) CountFold(substr string) int {
+	if substr == "" {
+		return self.Count("")
+	}
+	s := self.Value()
+	count := 0
+	for from := 0; ; count++ {
+		_, end := indexFold(s, substr, from)
+		if end < 0 {
+			return count
+		}
+		from = end
+	}
+}
+
+// ReplaceFold returns a copy of self with the first n non-overlapping
+// instances of old, found under simple Unicode case folding, replaced by
+// new. If n < 0, there is no limit on the number of replacements. An empty
+// old behaves as in [String.Replace].
+func (self String) ReplaceFold(old, new string, n int) String {
+	if old == "" || n == 0 {
+		return self.Replace(old, new, n)
+	}
+	s := self.Value()
+	var buf strings.Builder
+	last := 0
+	for done := 0; n < 0 || done < n; done++ {
+		start, end := indexFold(s, old, last)
+		if start < 0 {
+			break
+		}
+		buf.WriteString(s[last:start])
+		buf.WriteString(new)
+		last = end
+	}
+	if last == 0 {
+		return self
+	}
+	buf.WriteString(s[last:])
+	return New(buf.String())
+}
+
+// SplitFold slices self into all substrings separated by sep, found under
+// simple Unicode case folding, and returns a Slice of the substrings between
+// those separators. An empty sep behaves as in [String.Split].
+func (self String) SplitFold(sep string) Slice.Slice[String] {
+	if sep == "" {
+		return self.Split(sep)
+	}
+	s := self.Value()
+	parts := Slice.New([]String{})
+	last := 0
+	for {
+		start, end := indexFold(s, sep, last)
+		if start < 0 {
+			break
+		}
+		parts.Push(New(s[last:start]))
+		last = end
+	}
+	parts.Push(New(s[last:]))
+	return parts
+}
@@ -0,0 +1,164 @@
+package String
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/harishtpj/klassy/Char"
+)
+
+// caseWords splits self into words for the case conversions: words are
+// separated by characters other than letters and digits, by a lower case
+// letter or digit followed by an upper case one, and at the end of an
+// acronym, so "parseHTTPServer2" gives "parse", "HTTP" and "Server2"
+func (self String) caseWords() []string {
+	var words []string
+	runes := []rune(self.Value())
+	start := -1
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if start >= 0 {
+				words = append(words, string(runes[start:i]))
+				start = -1
+			}
+			continue
+		}
+		if start >= 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || unicode.IsUpper(prev) && nextLower {
+				words = append(words, string(runes[start:i]))
+				start = i
+			}
+		}
+		if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		words = append(words, string(runes[start:]))
+	}
+	return words
+}
+
+// toSnakeCase returns self in snake_case: its words in lower case joined by
+// underscores, so "parseHTTPServer" and "Parse HTTP server" both give
+// "parse_http_server".
+func (self String) toSnakeCase() String {
+	words := self.caseWords()
+	for i, word := range words {
+		words[i] = strings.ToLower(word)
+	}
+	return New(strings.Join(words, "_"))
+}
+
+// toCamelCase returns self in camelCase: its first word in lower case
+// followed by the others capitalized, so "parse_http_server" gives
+// "parseHttpServer".
+func (self String) toCamelCase() String {
+	words := self.caseWords()
+	var buf strings.Builder
+	for i, word := range words {
+		word = strings.ToLower(word)
+		if i > 0 {
+			r, size := utf8.DecodeRuneInString(word)
+			buf.WriteRune(unicode.ToTitle(r))
+			word = word[size:]
+		}
+		buf.WriteString(word)
+	}
+	return New(buf.String())
+}
+
+// padRight returns self followed by as many fill characters as needed to
+// make it width terminal columns wide, as measured by
+// [String.VisibleLength]. Strings already as wide are returned as is.
+func (self String) padRight(width int, fill rune) String {
+	if n := width - self.VisibleLength(); n > 0 {
+		return self + New(strings.Repeat(string(fill), n))
+	}
+	return self
+}
+
+// padLeft returns self preceded by as many fill characters as needed to
+// make it width terminal columns wide, as for padRight.
+func (self String) padLeft(width int, fill rune) String {
+	if n := width - self.VisibleLength(); n > 0 {
+		return New(strings.Repeat(string(fill), n)) + self
+	}
+	return self
+}
+
+// truncate returns self cut to at most width terminal columns, as measured
+// by [String.VisibleLength], with ellipsis replacing the end when it is cut,
+// so New("Hello, World").truncate(8, "…") gives "Hello, …". The ellipsis
+// counts towards the width. Escape sequences are never cut, and those after
+// the cut are kept, so styles are still reset.
+func (self String) truncate(width int, ellipsis string) String {
+	if self.VisibleLength() <= width {
+		return self
+	}
+	keep := max(width-New(ellipsis).VisibleLength(), 0)
+	s := self.Value()
+	var buf strings.Builder
+	cols, cut := 0, false
+	for i := 0; i < len(s); {
+		if n := ansiSequenceLen(s[i:]); n > 0 {
+			buf.WriteString(s[i : i+n])
+			i += n
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		i += size
+		if cut {
+			continue
+		}
+		if w := Char.New(r).Width(); cols+w <= keep {
+			buf.WriteString(s[i-size : i])
+			cols += w
+			continue
+		}
+		buf.WriteString(ellipsis)
+		cut = true
+	}
+	return New(buf.String())
+}
+
+// wrap breaks the lines of self at spaces so that no line is wider than
+// width terminal columns, where possible: words wider than width are kept
+// whole on a line of their own. Existing line breaks are kept, and white
+// space within a line is collapsed to single spaces.
+func (self String) wrap(width int) String {
+	var buf strings.Builder
+	for i, line := range strings.Split(self.Value(), "\n") {
+		if i > 0 {
+			buf.WriteByte('\n')
+		}
+		lineLen := 0
+		for j, word := range strings.Fields(line) {
+			n := New(word).VisibleLength()
+			switch {
+			case j == 0:
+			case lineLen+1+n > width:
+				buf.WriteByte('\n')
+				lineLen = 0
+			default:
+				buf.WriteByte(' ')
+				lineLen++
+			}
+			buf.WriteString(word)
+			lineLen += n
+		}
+	}
+	return New(buf.String())
+}
+
+// plural returns one if n is 1 and many otherwise, for messages such as
+// "1 file" and "3 files"
+func plural(n int, one, many string) String {
+	if n == 1 {
+		return New(one)
+	}
+	return New(many)
+}
@@ -0,0 +1,420 @@
+package String
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/harishtpj/klassy/Slice"
+)
+
+// ErrBadGlob is wrapped by the errors returned for malformed glob patterns
+var ErrBadGlob = errors.New("syntax error in glob pattern")
+
+// GlobOptions controls how glob patterns are matched. The zero value
+// matches case-sensitively and treats the text as a single name.
+type GlobOptions struct {
+	// IgnoreCase matches letters under simple Unicode case-folding
+	IgnoreCase bool
+	// Separator, when not zero, makes matching path-aware: '*', '?' and
+	// character classes never match the separator, and a "**" segment
+	// matches any number of whole path segments, including none.
+	Separator rune
+}
+
+// Glob is a compiled glob pattern which can be matched repeatedly without
+// being parsed again. It is safe for concurrent use.
+type Glob struct {
+	pattern string
+	opts    GlobOptions
+	alts    [][]globSegment // one entry per alternative after brace expansion
+}
+
+// globSegment is the part of a pattern between two separators
+type globSegment struct {
+	globstar bool // the segment is "**"
+	tokens   []globToken
+}
+
+// globTokenKind is the kind of a single token of a compiled glob segment
+type globTokenKind int
+
+const (
+	globLiteral globTokenKind = iota // a single literal character
+	globAny                          // '?'
+	globStar                         // '*'
+	globClass                        // '[...]'
+)
+
+// globToken is a single element of a compiled glob segment
+type globToken struct {
+	kind   globTokenKind
+	r      rune      // the literal character
+	negate bool      // the class starts with '!' or '^'
+	ranges [][2]rune // inclusive ranges of the class
+}
+
+// GlobMatch reports whether self matches the shell glob pattern. The
+// pattern supports '*' for any sequence of characters, '?' for any single
+// character, character classes such as "[a-z]" and "[!0-9]", alternatives
+// such as "{jpg,png}" and '\' to escape a special character. The only
+// possible error is one wrapping [ErrBadGlob], returned for malformed patterns.
+//
+// GlobMatch treats self as a single name; use [CompileGlob] with
+// [GlobOptions] for case-insensitive or path-aware matching.
+func (self String) GlobMatch(pattern string) (bool, error) {
+	g, err := CompileGlob(pattern, GlobOptions{})
+	if err != nil {
+		return false, err
+	}
+	return g.Match(self.Value()), nil
+}
+
+// CompileGlob parses a glob pattern, described in [String.GlobMatch], into
+// a [Glob] using the given options.
+func CompileGlob(pattern string, opts GlobOptions) (*Glob, error) {
+	expanded, err := expandBraces(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &Glob{pattern: pattern, opts: opts}
+	for _, alt := range expanded {
+		segments, err := compileGlobSegments(alt, opts)
+		if err != nil {
+			return nil, err
+		}
+		g.alts = append(g.alts, segments)
+	}
+	return g, nil
+}
+
+// MustCompileGlob is like [CompileGlob] but panics if the pattern is malformed.
+func MustCompileGlob(pattern string, opts GlobOptions) *Glob {
+	g, err := CompileGlob(pattern, opts)
+	if err != nil {
+		panic(err)
+	}
+	return g
+}
+
+// String returns the source pattern of g
+func (g *Glob) String() string {
+	return g.pattern
+}
+
+// Match reports whether name matches g.
+func (g *Glob) Match(name string) bool {
+	var parts []string
+	if g.opts.Separator != 0 {
+		parts = strings.Split(name, string(g.opts.Separator))
+	} else {
+		parts = []string{name}
+	}
+	for _, segments := range g.alts {
+		if g.matchSegments(segments, parts) {
+			return true
+		}
+	}
+	return false
+}
+
+// Filter returns a new Slice holding the elements of s which match g.
+func (g *Glob) Filter(s Slice.Slice[String]) Slice.Slice[String] {
+	result := Slice.New([]String{})
+	for v := range s.Values() {
+		if g.Match(v.Value()) {
+			result.Push(v)
+		}
+	}
+	return result
+}
+
+// matchSegments matches path segments against pattern segments, where a
+// globstar segment matches any number of path segments
+func (g *Glob) matchSegments(segments []globSegment, parts []string) bool {
+	si, pi := 0, 0
+	starSi, starPi := -1, 0
+	for pi < len(parts) {
+		switch {
+		case si < len(segments) && segments[si].globstar:
+			starSi, starPi = si, pi
+			si++
+		case si < len(segments) && g.matchTokens(segments[si].tokens, parts[pi]):
+			si++
+			pi++
+		case starSi >= 0:
+			// Let the last globstar swallow one more segment and retry
+			starPi++
+			si, pi = starSi+1, starPi
+		default:
+			return false
+		}
+	}
+	for si < len(segments) && segments[si].globstar {
+		si++
+	}
+	return si == len(segments)
+}
+
+// matchTokens matches a single name against the tokens of a segment. In
+// path-aware mode names are split at the separator beforehand, so no token
+// can match across it.
+func (g *Glob) matchTokens(tokens []globToken, name string) bool {
+	ti, ni := 0, 0
+	starTi, starNi := -1, 0
+	for ni < len(name) {
+		r, size := utf8.DecodeRuneInString(name[ni:])
+		if ti < len(tokens) {
+			switch tok := tokens[ti]; tok.kind {
+			case globStar:
+				starTi, starNi = ti, ni
+				ti++
+				continue
+			case globAny:
+				ti, ni = ti+1, ni+size
+				continue
+			case globLiteral:
+				if r == tok.r || g.opts.IgnoreCase && foldRune(r) == foldRune(tok.r) {
+					ti, ni = ti+1, ni+size
+					continue
+				}
+			case globClass:
+				if tok.matchClass(r, g.opts.IgnoreCase) {
+					ti, ni = ti+1, ni+size
+					continue
+				}
+			}
+		}
+		if starTi < 0 {
+			return false
+		}
+		// Let the last star swallow one more character and retry
+		_, size = utf8.DecodeRuneInString(name[starNi:])
+		starNi += size
+		ti, ni = starTi+1, starNi
+	}
+	for ti < len(tokens) && tokens[ti].kind == globStar {
+		ti++
+	}
+	return ti == len(tokens)
+}
+
+// matchClass reports whether r is matched by the character class tok
+func (tok globToken) matchClass(r rune, ignoreCase bool) bool {
+	in := func(c rune) bool {
+		for _, rg := range tok.ranges {
+			if rg[0] <= c && c <= rg[1] {
+				return true
+			}
+		}
+		return false
+	}
+	found := in(r)
+	if !found && ignoreCase {
+		found = in(unicode.ToLower(r)) || in(unicode.ToUpper(r))
+	}
+	return found != tok.negate
+}
+
+// compileGlobSegments parses a pattern without braces into segments
+func compileGlobSegments(pattern string, opts GlobOptions) ([]globSegment, error) {
+	var parts []string
+	if opts.Separator != 0 {
+		parts = splitUnescaped(pattern, opts.Separator)
+	} else {
+		parts = []string{pattern}
+	}
+
+	segments := make([]globSegment, 0, len(parts))
+	for _, part := range parts {
+		if part == "**" && opts.Separator != 0 {
+			// Consecutive globstars are equivalent to a single one
+			if n := len(segments); n == 0 || !segments[n-1].globstar {
+				segments = append(segments, globSegment{globstar: true})
+			}
+			continue
+		}
+		tokens, err := compileGlobTokens(part)
+		if err != nil {
+			return nil, err
+		}
+		segments = append(segments, globSegment{tokens: tokens})
+	}
+	return segments, nil
+}
+
+// compileGlobTokens parses a single segment of a pattern
+func compileGlobTokens(pattern string) ([]globToken, error) {
+	var tokens []globToken
+	for i := 0; i < len(pattern); {
+		r, size := utf8.DecodeRuneInString(pattern[i:])
+		switch r {
+		case '*':
+			// Runs of stars are equivalent to a single one
+			if n := len(tokens); n == 0 || tokens[n-1].kind != globStar {
+				tokens = append(tokens, globToken{kind: globStar})
+			}
+		case '?':
+			tokens = append(tokens, globToken{kind: globAny})
+		case '[':
+			tok, n, err := compileGlobClass(pattern[i:])
+			if err != nil {
+				return nil, fmt.Errorf("%w: %v at offset %d", ErrBadGlob, err, i)
+			}
+			tokens = append(tokens, tok)
+			size = n
+		case '\\':
+			if i+size >= len(pattern) {
+				return nil, fmt.Errorf("%w: trailing backslash", ErrBadGlob)
+			}
+			var n int
+			r, n = utf8.DecodeRuneInString(pattern[i+size:])
+			size += n
+			fallthrough
+		default:
+			tokens = append(tokens, globToken{kind: globLiteral, r: r})
+		}
+		i += size
+	}
+	return tokens, nil
+}
+
+// compileGlobClass parses the character class at the start of pattern and
+// returns it together with its length in bytes
+func compileGlobClass(pattern string) (globToken, int, error) {
+	tok := globToken{kind: globClass}
+	i := 1
+	if i < len(pattern) && (pattern[i] == '!' || pattern[i] == '^') {
+		tok.negate = true
+		i++
+	}
+
+	readRune := func() (rune, error) {
+		if i < len(pattern) && pattern[i] == '\\' {
+			i++
+		}
+		if i >= len(pattern) {
+			return 0, errors.New("unterminated character class")
+		}
+		r, size := utf8.DecodeRuneInString(pattern[i:])
+		i += size
+		return r, nil
+	}
+
+	for first := true; ; first = false {
+		if i >= len(pattern) {
+			return tok, 0, errors.New("unterminated character class")
+		}
+		// A ']' directly after the opening bracket is taken literally
+		if pattern[i] == ']' && !first {
+			return tok, i + 1, nil
+		}
+		lo, err := readRune()
+		if err != nil {
+			return tok, 0, err
+		}
+		hi := lo
+		if i+1 < len(pattern) && pattern[i] == '-' && pattern[i+1] != ']' {
+			i++
+			if hi, err = readRune(); err != nil {
+				return tok, 0, err
+			}
+			if hi < lo {
+				return tok, 0, fmt.Errorf("invalid range %c-%c", lo, hi)
+			}
+		}
+		tok.ranges = append(tok.ranges, [2]rune{lo, hi})
+	}
+}
+
+// splitUnescaped splits s around each occurrence of sep which is not
+// preceded by a backslash
+func splitUnescaped(s string, sep rune) []string {
+	var parts []string
+	start := 0
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case r == '\\':
+			_, n := utf8.DecodeRuneInString(s[min(i+size, len(s)):])
+			size += n
+		case r == sep:
+			parts = append(parts, s[start:i])
+			start = i + size
+		}
+		i += size
+	}
+	return append(parts, s[start:])
+}
+
+// expandBraces expands every "{a,b}" alternation of pattern, including
+// nested ones, into the list of patterns it stands for
+func expandBraces(pattern string) ([]string, error) {
+	open, inClass := -1, false
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; {
+		case c == '\\':
+			i++
+		case inClass:
+			if c == ']' {
+				inClass = false
+			}
+		case c == '[':
+			inClass = true
+			// Skip a leading negation and a literal ']' directly after it
+			if i+1 < len(pattern) && (pattern[i+1] == '!' || pattern[i+1] == '^') {
+				i++
+			}
+			if i+1 < len(pattern) && pattern[i+1] == ']' {
+				i++
+			}
+		case c == '{':
+			open = i
+		}
+		if open >= 0 {
+			break
+		}
+	}
+	if open < 0 {
+		return []string{pattern}, nil
+	}
+
+	// Find the matching brace and the top-level commas within it
+	depth, commas, end := 0, []int{}, -1
+	for i := open; i < len(pattern) && end < 0; i++ {
+		switch pattern[i] {
+		case '\\':
+			i++
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				end = i
+			}
+		case ',':
+			if depth == 1 {
+				commas = append(commas, i)
+			}
+		}
+	}
+	if end < 0 {
+		return nil, fmt.Errorf("%w: unterminated '{' at offset %d", ErrBadGlob, open)
+	}
+
+	prefix, suffix := pattern[:open], pattern[end+1:]
+	var result []string
+	start := open + 1
+	for _, stop := range append(commas, end) {
+		expanded, err := expandBraces(prefix + pattern[start:stop] + suffix)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, expanded...)
+		start = stop + 1
+	}
+	return result, nil
+}
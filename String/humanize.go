@@ -0,0 +1,327 @@
+package String
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ByteUnits selects the units used by [HumanBytes]
+type ByteUnits int
+
+const (
+	SI  ByteUnits = iota // powers of 1000: kB, MB, GB, ...
+	IEC                  // powers of 1024: KiB, MiB, GiB, ...
+)
+
+// Unit symbols used by HumanBytes, from kilo to exa
+var (
+	siByteUnits  = []string{"kB", "MB", "GB", "TB", "PB", "EB"}
+	iecByteUnits = []string{"KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+)
+
+// trimZeroDecimal formats v with one decimal, dropping it if it is zero
+func trimZeroDecimal(v float64) string {
+	return strings.TrimSuffix(strconv.FormatFloat(v, 'f', 1, 64), ".0")
+}
+
+// HumanBytes formats the size n in bytes with the largest unit in which it
+// is at least 1, with up to one decimal, such as "512 B", "1.5 kB" in [SI]
+// units or "1.4 KiB" in [IEC] units. [String.ToBytesSize] parses the result
+// back, to within the precision shown.
+func HumanBytes(n int64, units ByteUnits) String {
+	sign := ""
+	size := float64(n)
+	if n < 0 {
+		sign, size = "-", -size
+	}
+	base, symbols := 1000.0, siByteUnits
+	if units == IEC {
+		base, symbols = 1024, iecByteUnits
+	}
+	if size < base {
+		return New(fmt.Sprintf("%s%d B", sign, int64(size)))
+	}
+
+	unit := -1
+	for size >= base && unit < len(symbols)-1 {
+		size /= base
+		unit++
+	}
+	// Rounding may give "1000.0 kB", which is better shown as "1 MB"
+	if math.Round(size*10)/10 >= base && unit < len(symbols)-1 {
+		size /= base
+		unit++
+	}
+	return New(sign + trimZeroDecimal(size) + " " + symbols[unit])
+}
+
+// durationUnits are the units used by HumanDuration, from the largest
+var durationUnits = []struct {
+	symbol string
+	size   time.Duration
+}{
+	{"d", 24 * time.Hour},
+	{"h", time.Hour},
+	{"m", time.Minute},
+	{"s", time.Second},
+	{"ms", time.Millisecond},
+	{"µs", time.Microsecond},
+	{"ns", time.Nanosecond},
+}
+
+// HumanDuration formats d with its two most significant units, such as
+// "2h 3m", "3d 4h" or "1s 500ms", dropping the smaller remainder. Days are
+// the largest unit used. [String.ToHumanDuration] parses the result back.
+func HumanDuration(d time.Duration) String {
+	if d == 0 {
+		return New("0s")
+	}
+	sign := ""
+	if d < 0 {
+		sign = "-"
+		if d == math.MinInt64 {
+			d++ // -d would overflow; a nanosecond is lost to the truncation anyway
+		}
+		d = -d
+	}
+
+	var parts []string
+	for _, unit := range durationUnits {
+		if d >= unit.size {
+			parts = append(parts, strconv.FormatInt(int64(d/unit.size), 10)+unit.symbol)
+			d %= unit.size
+		} else if len(parts) > 0 {
+			// The second unit must follow the first directly, so "1h 0m 5s"
+			// is shown as "1h", not "1h 5s"
+			break
+		}
+		if len(parts) == 2 {
+			break
+		}
+	}
+	return New(sign + strings.Join(parts, " "))
+}
+
+// durationWords maps the unit names accepted by ToHumanDuration to their size
+var durationWords = map[string]time.Duration{
+	"ns": time.Nanosecond, "nanosecond": time.Nanosecond, "nanoseconds": time.Nanosecond,
+	"us": time.Microsecond, "µs": time.Microsecond, "μs": time.Microsecond,
+	"microsecond": time.Microsecond, "microseconds": time.Microsecond,
+	"ms": time.Millisecond, "millisecond": time.Millisecond, "milliseconds": time.Millisecond,
+	"s": time.Second, "sec": time.Second, "secs": time.Second, "second": time.Second, "seconds": time.Second,
+	"m": time.Minute, "min": time.Minute, "mins": time.Minute, "minute": time.Minute, "minutes": time.Minute,
+	"h": time.Hour, "hr": time.Hour, "hrs": time.Hour, "hour": time.Hour, "hours": time.Hour,
+	"d": 24 * time.Hour, "day": 24 * time.Hour, "days": 24 * time.Hour,
+	"w": 7 * 24 * time.Hour, "wk": 7 * 24 * time.Hour, "week": 7 * 24 * time.Hour, "weeks": 7 * 24 * time.Hour,
+}
+
+// ToHumanDuration interprets self as a duration written by a person or by
+// [HumanDuration], such as "2h 3m", "1.5 hours", "3d", "1 week, 2 days and
+// 4 hours" or "-90s". Unlike [String.ToDuration] it accepts days and weeks,
+// spelled out units and separating spaces, commas and "and". Units are
+// case-insensitive, and every number needs one.
+func (self String) ToHumanDuration() (time.Duration, error) {
+	text := strings.ToLower(self.TrimSpace().Value())
+	negative := false
+	if rest, ok := strings.CutPrefix(text, "-"); ok {
+		negative, text = true, rest
+	} else {
+		text = strings.TrimPrefix(text, "+")
+	}
+
+	var total float64
+	components := 0
+	for {
+		text = strings.TrimLeft(text, " \t,")
+		if rest, ok := strings.CutPrefix(text, "and "); ok && components > 0 {
+			text = strings.TrimLeft(rest, " \t")
+		}
+		if text == "" {
+			break
+		}
+		end := strings.IndexFunc(text, func(r rune) bool { return !(r >= '0' && r <= '9' || r == '.') })
+		if end == 0 {
+			return 0, convError("ToHumanDuration", self, fmt.Errorf("expected a number at %q", text))
+		}
+		if end < 0 {
+			return 0, convError("ToHumanDuration", self, fmt.Errorf("missing unit after %q", text))
+		}
+		n, err := strconv.ParseFloat(text[:end], 64)
+		if err != nil {
+			return 0, convError("ToHumanDuration", self, err)
+		}
+		text = strings.TrimLeft(text[end:], " \t")
+		unitEnd := strings.IndexAny(text, "0123456789 \t,.")
+		if unitEnd < 0 {
+			unitEnd = len(text)
+		}
+		size, ok := durationWords[text[:unitEnd]]
+		if !ok {
+			if unitEnd == 0 {
+				return 0, convError("ToHumanDuration", self, errors.New("missing unit"))
+			}
+			return 0, convError("ToHumanDuration", self, fmt.Errorf("unknown unit %q", text[:unitEnd]))
+		}
+		total += n * float64(size)
+		components++
+		text = text[unitEnd:]
+	}
+	if components == 0 {
+		return 0, convError("ToHumanDuration", self, errors.New("empty duration"))
+	}
+	if total >= math.MaxInt64 {
+		return 0, convError("ToHumanDuration", self, strconv.ErrRange)
+	}
+	d := time.Duration(math.Round(total))
+	if negative {
+		d = -d
+	}
+	return d, nil
+}
+
+// relativeUnits are the units used by RelativeTime, from the largest
+var relativeUnits = []struct {
+	name string
+	size time.Duration
+}{
+	{"year", 365 * 24 * time.Hour},
+	{"month", 30 * 24 * time.Hour},
+	{"week", 7 * 24 * time.Hour},
+	{"day", 24 * time.Hour},
+	{"hour", time.Hour},
+	{"minute", time.Minute},
+	{"second", time.Second},
+}
+
+// RelativeTime describes t relative to now in the largest whole unit, such
+// as "3 days ago" or "in 5 minutes". Times less than a second from now give
+// "just now"; months are 30 days and years 365 days.
+func RelativeTime(t, now time.Time) String {
+	d := t.Sub(now)
+	future := d > 0
+	if !future {
+		d = -d
+	}
+	for _, unit := range relativeUnits {
+		if d < unit.size {
+			continue
+		}
+		n := int64(d / unit.size)
+		text := strconv.FormatInt(n, 10) + " " + unit.name
+		if n != 1 {
+			text += "s"
+		}
+		if future {
+			return New("in " + text)
+		}
+		return New(text + " ago")
+	}
+	return New("just now")
+}
+
+// Comma formats n with a comma between each group of three digits, such as
+// "1,234,567"
+func Comma(n int64) String {
+	digits := strconv.FormatInt(n, 10)
+	sign := ""
+	if n < 0 {
+		sign, digits = "-", digits[1:]
+	}
+	var buf strings.Builder
+	buf.WriteString(sign)
+	for i, c := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			buf.WriteByte(',')
+		}
+		buf.WriteRune(c)
+	}
+	return New(buf.String())
+}
+
+// Compact formats n with a short scale suffix and up to one decimal, such as
+// "950", "1.2K", "3.4M", "5B" or "7.1T", as used for counts on dashboards
+func Compact(n int64) String {
+	sign := ""
+	v := float64(n)
+	if n < 0 {
+		sign, v = "-", -v
+	}
+	suffixes := []string{"", "K", "M", "B", "T", "Q"}
+	unit := 0
+	for v >= 1000 && unit < len(suffixes)-1 {
+		v /= 1000
+		unit++
+	}
+	// Rounding may give "1000K", which is better shown as "1M"
+	if unit > 0 && math.Round(v*10)/10 >= 1000 && unit < len(suffixes)-1 {
+		v /= 1000
+		unit++
+	}
+	if unit == 0 {
+		return New(sign + strconv.FormatInt(int64(v), 10))
+	}
+	return New(sign + trimZeroDecimal(v) + suffixes[unit])
+}
+
+// Number names used by SpellNumber
+var (
+	smallNumbers = []string{
+		"zero", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine",
+		"ten", "eleven", "twelve", "thirteen", "fourteen", "fifteen", "sixteen",
+		"seventeen", "eighteen", "nineteen",
+	}
+	tensNumbers = []string{"", "", "twenty", "thirty", "forty", "fifty", "sixty", "seventy", "eighty", "ninety"}
+	scaleNames  = []string{"", "thousand", "million", "billion", "trillion", "quadrillion", "quintillion"}
+)
+
+// SpellNumber spells n out in English words on the short scale, without
+// "and", such as "one hundred twenty" or "minus forty-two".
+func SpellNumber(n int64) String {
+	if n == 0 {
+		return New(smallNumbers[0])
+	}
+	var words []string
+	if n < 0 {
+		words = append(words, "minus")
+	}
+	// Work with the magnitude as a uint64 so math.MinInt64 is handled
+	u := uint64(n)
+	if n < 0 {
+		u = -u
+	}
+
+	var groups []string
+	for scale := 0; u > 0; scale++ {
+		if group := u % 1000; group > 0 {
+			text := spellHundreds(int(group))
+			if scaleNames[scale] != "" {
+				text += " " + scaleNames[scale]
+			}
+			groups = append([]string{text}, groups...)
+		}
+		u /= 1000
+	}
+	return New(strings.Join(append(words, groups...), " "))
+}
+
+// spellHundreds spells out n between 1 and 999
+func spellHundreds(n int) string {
+	var words []string
+	if n >= 100 {
+		words = append(words, smallNumbers[n/100], "hundred")
+		n %= 100
+	}
+	switch {
+	case n >= 20 && n%10 != 0:
+		words = append(words, tensNumbers[n/10]+"-"+smallNumbers[n%10])
+	case n >= 20:
+		words = append(words, tensNumbers[n/10])
+	case n > 0:
+		words = append(words, smallNumbers[n])
+	}
+	return strings.Join(words, " ")
+}
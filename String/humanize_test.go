@@ -0,0 +1,58 @@
+package String
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHumanBytesRoundTrip(t *testing.T) {
+	for _, n := range []int64{0, 1, 999, 1000, 1536, -1536, 1 << 20, -5 << 30, 123456789} {
+		for _, units := range []ByteUnits{SI, IEC} {
+			text := HumanBytes(n, units)
+			got, err := text.ToBytesSize()
+			if err != nil {
+				t.Errorf("HumanBytes(%d) = %q does not parse: %v", n, text, err)
+				continue
+			}
+			// One decimal is shown, so the size comes back within 5% of a unit
+			if diff := float64(got - n); diff < -0.05*float64(abs64(n))-1 || diff > 0.05*float64(abs64(n))+1 {
+				t.Errorf("HumanBytes(%d) = %q parses back as %d", n, text, got)
+			}
+		}
+	}
+	if got := HumanBytes(-1536, IEC); got != "-1.5 KiB" {
+		t.Errorf("HumanBytes(-1536, IEC) = %q", got)
+	}
+}
+
+func abs64(n int64) int64 {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+func TestHumanDurationRoundTrip(t *testing.T) {
+	for _, d := range []time.Duration{0, time.Second, 90 * time.Second, 2*time.Hour + 3*time.Minute, 3*24*time.Hour + 4*time.Hour, -1500 * time.Millisecond} {
+		text := HumanDuration(d)
+		got, err := text.ToHumanDuration()
+		if err != nil || got != d {
+			t.Errorf("HumanDuration(%v) = %q parses back as %v, %v", d, text, got, err)
+		}
+	}
+}
+
+func TestHumanNumbers(t *testing.T) {
+	tests := []struct{ got, want String }{
+		{Comma(-1234567), "-1,234,567"},
+		{Compact(999_950), "1M"},
+		{Compact(1234), "1.2K"},
+		{SpellNumber(-42), "minus forty-two"},
+		{SpellNumber(120), "one hundred twenty"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("got %q, want %q", tt.got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package String
+
+import (
+	"strings"
+)
+
+// splitLineEnding splits line into its content and its terminating
+// "\n" or "\r\n", if any
+func splitLineEnding(line string) (content, ending string) {
+	if c, found := strings.CutSuffix(line, "\r\n"); found {
+		return c, "\r\n"
+	}
+	if c, found := strings.CutSuffix(line, "\n"); found {
+		return c, "\n"
+	}
+	return line, ""
+}
+
+// isBlank reports whether s only contains spaces and tabs
+func isBlank(s string) bool {
+	return strings.TrimLeft(s, " \t") == ""
+}
+
+// Dedent removes any whitespace prefix common to every line of self, so
+// that text indented to fit Go source can be written flush left. Lines
+// consisting only of spaces and tabs are ignored when finding the prefix
+// and are reduced to their line ending. Tabs and spaces are not treated as
+// equal, so "  hello" and "\thello" have no common prefix. Both "\n" and
+// "\r\n" line endings are kept as they are.
+func (self String) Dedent() String {
+	var margin string
+	first := true
+	for line := range self.Lines() {
+		content, _ := splitLineEnding(line.Value())
+		if isBlank(content) {
+			continue
+		}
+		indent := content[:len(content)-len(strings.TrimLeft(content, " \t"))]
+		if first {
+			margin, first = indent, false
+			continue
+		}
+		// Shrink margin to the part it shares with indent
+		n := 0
+		for n < len(margin) && n < len(indent) && margin[n] == indent[n] {
+			n++
+		}
+		margin = margin[:n]
+	}
+
+	var buf strings.Builder
+	buf.Grow(self.Length())
+	for line := range self.Lines() {
+		content, ending := splitLineEnding(line.Value())
+		if isBlank(content) {
+			buf.WriteString(ending)
+			continue
+		}
+		buf.WriteString(strings.TrimPrefix(content, margin))
+		buf.WriteString(ending)
+	}
+	return New(buf.String())
+}
+
+// Indent adds prefix to the beginning of the lines of self for which
+// predicate returns true. The predicate is given each line as yielded by
+// [String.Lines], including its line ending. If predicate is nil, prefix is
+// added to every line that does not consist solely of whitespace.
+func (self String) Indent(prefix string, predicate func(line String) bool) String {
+	if predicate == nil {
+		predicate = func(line String) bool { return line.TrimSpace() != "" }
+	}
+
+	var buf strings.Builder
+	for line := range self.Lines() {
+		if predicate(line) {
+			buf.WriteString(prefix)
+		}
+		buf.WriteString(line.Value())
+	}
+	return New(buf.String())
+}
+
+// Heredoc normalizes a multi-line raw string literal written inline in Go
+// source. It removes the line break directly after the opening quote, drops
+// a final line holding only the indentation of the closing quote and then
+// removes the common indentation with [String.Dedent]. For example
+//
+//	String.New(`
+//		SELECT *
+//		FROM users
+//		`).Heredoc()
+//
+// returns "SELECT *\nFROM users\n".
+func (self String) Heredoc() String {
+	s := self.Value()
+	if content, ending := splitLineEnding(firstLine(s)); ending != "" && isBlank(content) {
+		s = s[len(content)+len(ending):]
+	}
+	if i := strings.LastIndexByte(s, '\n'); isBlank(s[i+1:]) {
+		s = s[:i+1]
+	}
+	return New(s).Dedent()
+}
+
+// firstLine returns the first line of s including its line ending
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i+1]
+	}
+	return s
+}
@@ -0,0 +1,176 @@
+package String
+
+import (
+	"hash/maphash"
+	"runtime"
+	"strings"
+	"sync"
+	"unsafe"
+	"weak"
+
+	"github.com/harishtpj/klassy/Slice"
+)
+
+// InternerOptions configures an [Interner]. The zero value keeps every
+// interned value for the lifetime of the Interner.
+type InternerOptions struct {
+	// MaxEntries bounds the number of distinct values kept. When the bound
+	// is reached an arbitrary entry is evicted to make room. Zero means no
+	// bound. It is ignored in weak mode.
+	MaxEntries int
+	// Weak keeps values only while they are referenced outside the
+	// Interner, so that values which are no longer used can be collected.
+	Weak bool
+}
+
+// InternStats reports the activity of an [Interner]
+type InternStats struct {
+	Lookups    int64 // calls to Intern
+	Hits       int64 // lookups which found an existing value
+	Unique     int   // distinct values currently held
+	BytesSaved int64 // bytes of duplicate values which were deduplicated
+}
+
+// Interner deduplicates equal String values, so that many copies of the
+// same text share one allocation. It is safe for concurrent use.
+type Interner struct {
+	opts InternerOptions
+	seed maphash.Seed
+
+	mu     sync.Mutex
+	strong map[string]string
+	weak   map[uint64][]weakEntry // weak mode entries by hash of their text
+	live   int                    // live entries in weak mode
+	stats  InternStats
+}
+
+// weakEntry refers to the bytes of an interned value without keeping them alive
+type weakEntry struct {
+	data weak.Pointer[byte]
+	n    int
+}
+
+// NewInterner returns an empty [Interner] configured by opts
+func NewInterner(opts InternerOptions) *Interner {
+	in := &Interner{opts: opts, seed: maphash.MakeSeed()}
+	if opts.Weak {
+		in.weak = map[uint64][]weakEntry{}
+	} else {
+		in.strong = map[string]string{}
+	}
+	return in
+}
+
+// Intern returns the canonical copy of s: the first value equal to s that
+// was interned and is still held. The returned String never shares memory
+// with s unless s itself became the canonical copy.
+func (in *Interner) Intern(s String) String {
+	if s == "" {
+		return s
+	}
+	in.mu.Lock()
+	defer in.mu.Unlock()
+
+	in.stats.Lookups++
+	if in.opts.Weak {
+		return New(in.internWeak(s.Value()))
+	}
+
+	if v, ok := in.strong[s.Value()]; ok {
+		in.stats.Hits++
+		in.stats.BytesSaved += int64(len(v))
+		return New(v)
+	}
+	if in.opts.MaxEntries > 0 && len(in.strong) >= in.opts.MaxEntries {
+		for k := range in.strong {
+			delete(in.strong, k)
+			break
+		}
+	}
+	// Clone so that a small value does not pin a larger buffer it was cut from
+	v := strings.Clone(s.Value())
+	in.strong[v] = v
+	return New(v)
+}
+
+// internWeak looks up or adds s in the weak table. in.mu must be held.
+func (in *Interner) internWeak(s string) string {
+	h := maphash.String(in.seed, s)
+	for _, e := range in.weak[h] {
+		if p := e.data.Value(); p != nil && e.n == len(s) && unsafe.String(p, e.n) == s {
+			in.stats.Hits++
+			in.stats.BytesSaved += int64(len(s))
+			return unsafe.String(p, e.n)
+		}
+	}
+
+	v := strings.Clone(s)
+	data := unsafe.StringData(v)
+	in.weak[h] = append(in.weak[h], weakEntry{data: weak.Make(data), n: len(v)})
+	in.live++
+	runtime.AddCleanup(data, in.removeDead, h)
+	return v
+}
+
+// removeDead drops the collected entries with hash h from the weak table
+func (in *Interner) removeDead(h uint64) {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+
+	entries := in.weak[h][:0]
+	for _, e := range in.weak[h] {
+		if e.data.Value() != nil {
+			entries = append(entries, e)
+		} else {
+			in.live--
+		}
+	}
+	if len(entries) == 0 {
+		delete(in.weak, h)
+	} else {
+		in.weak[h] = entries
+	}
+}
+
+// InternAll replaces every element of s in place with its canonical copy
+func (in *Interner) InternAll(s *Slice.Slice[String]) {
+	for i, v := range s.Items {
+		s.Items[i] = in.Intern(v)
+	}
+}
+
+// Len returns the number of distinct values currently held
+func (in *Interner) Len() int {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	if in.opts.Weak {
+		return in.live
+	}
+	return len(in.strong)
+}
+
+// Stats returns a snapshot of the activity of the Interner
+func (in *Interner) Stats() InternStats {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	stats := in.stats
+	if in.opts.Weak {
+		stats.Unique = in.live
+	} else {
+		stats.Unique = len(in.strong)
+	}
+	return stats
+}
+
+// Reset removes every value from the Interner and clears its statistics
+func (in *Interner) Reset() {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	if in.opts.Weak {
+		in.weak = map[uint64][]weakEntry{}
+		in.live = 0
+	} else {
+		in.strong = map[string]string{}
+	}
+	in.stats = InternStats{}
+}
@@ -0,0 +1,306 @@
+package String
+
+import (
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/harishtpj/klassy/Slice"
+)
+
+// Match is a single occurrence of a pattern found by a [Matcher]
+type Match struct {
+	Pattern int // index of the pattern in the Slice the Matcher was built from
+	Start   int // byte offset of the first byte of the occurrence
+	End     int // byte offset just after the occurrence
+}
+
+// Matcher searches text for many patterns at once using the Aho-Corasick
+// algorithm, so that the time taken is linear in the length of the text
+// regardless of the number of patterns. A Matcher is safe for concurrent use.
+type Matcher struct {
+	patterns   []string
+	ignoreCase bool
+	nodes      []acNode
+	maxDepth   int // length in runes of the longest pattern
+
+	reverseOnce sync.Once
+	reverse     *Matcher // the reversed patterns, for leftmost-longest scans
+}
+
+// acNode is a state of the Aho-Corasick automaton
+type acNode struct {
+	next   map[rune]int // goto transitions
+	fail   int          // state reached when no transition matches
+	depth  int          // number of runes on the path from the root
+	output int          // longest pattern ending here, or -1
+	dict   int          // nearest state on the fail chain with an output, or -1
+}
+
+// NewMatcher builds a [Matcher] for the given patterns. If ignoreCase is set,
+// patterns match regardless of case under simple Unicode case-folding.
+// Empty patterns never match.
+func NewMatcher(patterns Slice.Slice[String], ignoreCase bool) *Matcher {
+	m := &Matcher{ignoreCase: ignoreCase}
+	m.nodes = append(m.nodes, acNode{next: map[rune]int{}, output: -1, dict: -1})
+
+	for id, p := range patterns.Items {
+		m.patterns = append(m.patterns, p.Value())
+		if p == "" {
+			continue
+		}
+		state := 0
+		for _, r := range p.Value() {
+			r = m.fold(r)
+			next, ok := m.nodes[state].next[r]
+			if !ok {
+				next = len(m.nodes)
+				m.nodes = append(m.nodes, acNode{
+					next:   map[rune]int{},
+					depth:  m.nodes[state].depth + 1,
+					output: -1,
+					dict:   -1,
+				})
+				m.nodes[state].next[r] = next
+			}
+			state = next
+		}
+		m.maxDepth = max(m.maxDepth, m.nodes[state].depth)
+		// Duplicate patterns report the first index they were given at
+		if m.nodes[state].output < 0 {
+			m.nodes[state].output = id
+		}
+	}
+
+	// Compute the failure links breadth-first, in the order of depth
+	queue := []int{}
+	for _, child := range m.nodes[0].next {
+		queue = append(queue, child)
+	}
+	for len(queue) > 0 {
+		state := queue[0]
+		queue = queue[1:]
+		for r, child := range m.nodes[state].next {
+			fail := m.nodes[state].fail
+			for {
+				if next, ok := m.nodes[fail].next[r]; ok && next != child {
+					m.nodes[child].fail = next
+					break
+				}
+				if fail == 0 {
+					m.nodes[child].fail = 0
+					break
+				}
+				fail = m.nodes[fail].fail
+			}
+			f := m.nodes[child].fail
+			if m.nodes[f].output >= 0 {
+				m.nodes[child].dict = f
+			} else {
+				m.nodes[child].dict = m.nodes[f].dict
+			}
+			queue = append(queue, child)
+		}
+	}
+	return m
+}
+
+// fold maps r to the form used in the automaton
+func (m *Matcher) fold(r rune) rune {
+	if m.ignoreCase {
+		return foldRune(r)
+	}
+	return r
+}
+
+// Patterns returns the patterns the Matcher was built from
+func (m *Matcher) Patterns() Slice.Slice[String] {
+	return Slice.MapTo(Slice.New(m.patterns), New)
+}
+
+// step returns the state reached from state on reading r
+func (m *Matcher) step(state int, r rune) int {
+	for {
+		if next, ok := m.nodes[state].next[r]; ok {
+			return next
+		}
+		if state == 0 {
+			return 0
+		}
+		state = m.nodes[state].fail
+	}
+}
+
+// scan calls fn for every occurrence of every pattern in text, in order of
+// their end offsets, stopping early if fn returns false
+func (m *Matcher) scan(text string, fn func(Match) bool) {
+	// starts is a ring buffer of the offsets of the most recent runes, so
+	// that the start of a match can be found from its length in runes
+	starts := make([]int, m.maxDepth+1)
+	state, n := 0, 0
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		starts[n%len(starts)] = i
+		n++
+		i += size
+		end := i
+		state = m.step(state, m.fold(r))
+		for s := state; s > 0; s = m.nodes[s].dict {
+			if m.nodes[s].output < 0 {
+				continue
+			}
+			start := starts[(n-m.nodes[s].depth)%len(starts)]
+			if !fn(Match{Pattern: m.nodes[s].output, Start: start, End: end}) {
+				return
+			}
+		}
+	}
+}
+
+// FindAll returns every occurrence of every pattern in text, including
+// overlapping ones, ordered by start offset and then by length, longest first.
+func (m *Matcher) FindAll(text string) Slice.Slice[Match] {
+	var matches []Match
+	m.scan(text, func(match Match) bool {
+		matches = append(matches, match)
+		return true
+	})
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Start != matches[j].Start {
+			return matches[i].Start < matches[j].Start
+		}
+		return matches[i].End > matches[j].End
+	})
+	return Slice.New(matches)
+}
+
+// reversed returns a Matcher for the patterns of m spelt backwards, built on
+// first use
+func (m *Matcher) reversed() *Matcher {
+	m.reverseOnce.Do(func() {
+		patterns := make([]String, len(m.patterns))
+		for i, p := range m.patterns {
+			runes := []rune(p)
+			slices.Reverse(runes)
+			patterns[i] = New(string(runes))
+		}
+		m.reverse = NewMatcher(Slice.New(patterns), m.ignoreCase)
+	})
+	return m.reverse
+}
+
+// scanLeftmost calls fn for the leftmost-longest occurrences of the
+// patterns in text, in order, stopping early if fn returns false. The
+// automaton of the reversed patterns is run once from the end of the text,
+// which gives the longest match starting at every offset; the matches are
+// then picked greedily from the left, so the time is linear in the length
+// of the text.
+func (m *Matcher) scanLeftmost(text string, fn func(Match) bool) {
+	rev := m.reversed()
+	// ends is a ring buffer of the end offsets of the most recently read
+	// runes, so that the end of a match can be found from its length in runes
+	ends := make([]int, rev.maxDepth+1)
+	var longest []Match // the longest match at each start, last start first
+	state, n := 0, 0
+	for i := len(text); i > 0; {
+		r, size := utf8.DecodeLastRuneInString(text[:i])
+		ends[n%len(ends)] = i
+		n++
+		i -= size
+		state = rev.step(state, rev.fold(r))
+		s := state
+		if rev.nodes[s].output < 0 {
+			s = rev.nodes[s].dict
+		}
+		if s > 0 {
+			end := ends[(n-rev.nodes[s].depth)%len(ends)]
+			longest = append(longest, Match{Pattern: rev.nodes[s].output, Start: i, End: end})
+		}
+	}
+
+	pos := 0
+	for i := len(longest) - 1; i >= 0; i-- {
+		if longest[i].Start < pos {
+			continue
+		}
+		if !fn(longest[i]) {
+			return
+		}
+		pos = longest[i].End
+	}
+}
+
+// FindLeftmostLongest returns the non-overlapping occurrences of the
+// patterns in text, scanning left to right and picking the longest pattern
+// starting at the leftmost position each time.
+func (m *Matcher) FindLeftmostLongest(text string) Slice.Slice[Match] {
+	var matches []Match
+	m.scanLeftmost(text, func(match Match) bool {
+		matches = append(matches, match)
+		return true
+	})
+	return Slice.New(matches)
+}
+
+// ContainsAnyOf reports whether any of the patterns of m occurs in self.
+func (self String) ContainsAnyOf(m *Matcher) bool {
+	found := false
+	m.scan(self.Value(), func(Match) bool {
+		found = true
+		return false
+	})
+	return found
+}
+
+// FindAll returns every occurrence of the patterns of m in self, as
+// described in [Matcher.FindAll].
+func (self String) FindAll(m *Matcher) Slice.Slice[Match] {
+	return m.FindAll(self.Value())
+}
+
+// ReplaceMany returns a copy of self with the non-overlapping occurrences of
+// the keys of replacements replaced by their values. When several keys match
+// at the same position, the longest one wins, and matching resumes after the
+// replaced text. If ignoreCase is set, keys match regardless of case.
+//
+// ReplaceMany compiles a new [Matcher] on every call; use
+// [Matcher.ReplaceMany] to apply the same replacements to many texts.
+func (self String) ReplaceMany(replacements map[string]string, ignoreCase bool) String {
+	keys := make([]string, 0, len(replacements))
+	for k := range replacements {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	values := make([]string, len(keys))
+	for i, k := range keys {
+		values[i] = replacements[k]
+	}
+	m := NewMatcher(Slice.MapTo(Slice.New(keys), New), ignoreCase)
+	return m.ReplaceMany(self, Slice.MapTo(Slice.New(values), New))
+}
+
+// ReplaceMany returns a copy of text with the leftmost-longest occurrences
+// of the patterns of m replaced by the element of replacements at the same
+// index. It panics if replacements is shorter than the list of patterns.
+func (m *Matcher) ReplaceMany(text String, replacements Slice.Slice[String]) String {
+	if replacements.Length() < len(m.patterns) {
+		panic("String: fewer replacements than patterns")
+	}
+	s := text.Value()
+	var buf strings.Builder
+	last := 0
+	m.scanLeftmost(s, func(match Match) bool {
+		buf.WriteString(s[last:match.Start])
+		buf.WriteString(replacements.At(match.Pattern).Value())
+		last = match.End
+		return true
+	})
+	if last == 0 {
+		return text
+	}
+	buf.WriteString(s[last:])
+	return New(buf.String())
+}
@@ -0,0 +1,153 @@
+package String
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/harishtpj/klassy/Slice"
+)
+
+// naiveLeftmostLongest finds the leftmost-longest matches of patterns in
+// text by trying every pattern at every offset
+func naiveLeftmostLongest(patterns []string, text string) []Match {
+	var matches []Match
+	for pos := 0; pos < len(text); {
+		found := false
+		for start := pos; start < len(text) && !found; start++ {
+			best := Match{Pattern: -1}
+			for id, p := range patterns {
+				if p != "" && strings.HasPrefix(text[start:], p) && len(p) > best.End-best.Start {
+					best = Match{Pattern: id, Start: start, End: start + len(p)}
+				}
+			}
+			if best.Pattern >= 0 {
+				matches = append(matches, best)
+				pos, found = best.End, true
+			}
+		}
+		if !found {
+			break
+		}
+	}
+	return matches
+}
+
+// naiveFindAll finds every occurrence of every pattern in text
+func naiveFindAll(patterns []string, text string) int {
+	n := 0
+	for start := range text {
+		for _, p := range patterns {
+			if p != "" && strings.HasPrefix(text[start:], p) {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+func TestMatcherAgainstNaive(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	word := func(n int) string {
+		b := make([]byte, n)
+		for i := range b {
+			b[i] = "abc"[rng.Intn(3)]
+		}
+		return string(b)
+	}
+	for round := 0; round < 500; round++ {
+		// Distinct patterns, since duplicates report only the first index
+		seen := map[string]bool{}
+		var patterns []string
+		for len(patterns) < 1+rng.Intn(6) {
+			if p := word(1 + rng.Intn(4)); !seen[p] {
+				seen[p] = true
+				patterns = append(patterns, p)
+			}
+		}
+		text := word(rng.Intn(40))
+		m := NewMatcher(Slice.MapTo(Slice.New(patterns), New), false)
+
+		got := m.FindLeftmostLongest(text).Items
+		want := naiveLeftmostLongest(patterns, text)
+		if len(got) != len(want) {
+			t.Fatalf("FindLeftmostLongest(%q) with %q = %v, want %v", text, patterns, got, want)
+		}
+		for i := range got {
+			if got[i] != want[i] {
+				t.Fatalf("FindLeftmostLongest(%q) with %q = %v, want %v", text, patterns, got, want)
+			}
+		}
+		if got, want := m.FindAll(text).Length(), naiveFindAll(patterns, text); got != want {
+			t.Fatalf("FindAll(%q) with %q found %d matches, want %d", text, patterns, got, want)
+		}
+	}
+}
+
+func TestMatcherIgnoreCase(t *testing.T) {
+	m := NewMatcher(Slice.New([]String{"he", "she", "HERS"}), true)
+	got := m.FindLeftmostLongest("USHERS and She")
+	want := []Match{{Pattern: 1, Start: 1, End: 4}, {Pattern: 1, Start: 11, End: 14}}
+	if got.Length() != len(want) || got.At(0) != want[0] || got.At(1) != want[1] {
+		t.Errorf("FindLeftmostLongest = %v, want %v", got.Items, want)
+	}
+}
+
+func TestReplaceMany(t *testing.T) {
+	tests := []struct {
+		text String
+		repl map[string]string
+		want String
+	}{
+		{"a cat and a dog", map[string]string{"cat": "dog", "dog": "cat"}, "a dog and a cat"},
+		{"abcd", map[string]string{"bc": "X", "abcd": "Y"}, "Y"},
+		{"abcx", map[string]string{"bc": "X", "abcd": "Y"}, "aXx"},
+		{"nothing here", map[string]string{"zzz": "y"}, "nothing here"},
+		{"Hello HELLO", map[string]string{"hello": "bye"}, "Hello HELLO"},
+	}
+	for _, tt := range tests {
+		if got := tt.text.ReplaceMany(tt.repl, false); got != tt.want {
+			t.Errorf("%q.ReplaceMany(%v) = %q, want %q", tt.text, tt.repl, got, tt.want)
+		}
+	}
+	if got := New("Hello HELLO").ReplaceMany(map[string]string{"hello": "bye"}, true); got != "bye bye" {
+		t.Errorf("ReplaceMany ignoring case = %q, want %q", got, "bye bye")
+	}
+}
+
+func TestReplaceManyNested(t *testing.T) {
+	repl := map[string]string{}
+	for i := 1; i <= 200; i++ {
+		repl[strings.Repeat("a", i)] = "x"
+	}
+	got := New(strings.Repeat("a", 100_000)).ReplaceMany(repl, false)
+	if want := strings.Repeat("x", 500); got.Value() != want {
+		t.Fatalf("ReplaceMany gave %d bytes, want %d", len(got.Value()), len(want))
+	}
+}
+
+func TestReplaceManyScaling(t *testing.T) {
+	// A pattern which almost matches everywhere must not make the time grow
+	// with its length: {"a", "aaa...ab"} over a run of "a" used to rescan the
+	// whole long pattern after every replacement
+	text := New(strings.Repeat("a", 50_000))
+	cost := func(long int) time.Duration {
+		m := NewMatcher(Slice.New([]String{"a", New(strings.Repeat("a", long) + "b")}), false)
+		repl := Slice.New([]String{"x", "y"})
+		m.ReplaceMany("a", repl) // build the reversed automaton
+		best := time.Duration(1<<63 - 1)
+		for range 5 {
+			start := time.Now()
+			if got := m.ReplaceMany(text, repl); len(got) != len(text) || got[0] != 'x' {
+				t.Fatalf("ReplaceMany gave %d bytes", len(got))
+			}
+			best = min(best, time.Since(start))
+		}
+		return best
+	}
+	short, long := cost(10), cost(2000)
+	if long > 10*short {
+		t.Errorf("pattern 200 times longer made ReplaceMany %.1f times slower", float64(long)/float64(short))
+	}
+}
@@ -0,0 +1,256 @@
+package String
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/harishtpj/klassy/Slice"
+)
+
+// NaturalOptions controls how natural ordering compares two strings.
+// The zero value compares ASCII digit runs numerically and every other
+// character by its code point.
+type NaturalOptions struct {
+	// IgnoreCase compares letters under simple Unicode case-folding
+	IgnoreCase bool
+	// IgnoreLeadingZeros treats "007" and "7" as equal numbers. Otherwise
+	// the run with fewer leading zeros sorts first when the values are equal.
+	IgnoreLeadingZeros bool
+	// UnicodeDigits treats every decimal digit in Unicode (category Nd) as a
+	// digit, instead of only '0' to '9'
+	UnicodeDigits bool
+}
+
+// NaturalCompare compares self and other in natural order, where runs of
+// digits are compared by their numeric value, so that "file2" sorts before
+// "file10". The result is 0 if self == other, -1 if self < other and +1 if
+// self > other.
+func (self String) NaturalCompare(other string) int {
+	return NaturalOptions{}.Compare(self.Value(), other)
+}
+
+// NaturalLess reports whether self sorts before other in natural order.
+func (self String) NaturalLess(other string) bool {
+	return self.NaturalCompare(other) < 0
+}
+
+// SortNatural sorts the Slice s in place in natural order, keeping the
+// original order of elements which compare equal.
+func SortNatural(s *Slice.Slice[String]) {
+	NaturalOptions{}.Sort(s)
+}
+
+// Compare compares a and b in natural order using the options in opts.
+// The result is 0 if a == b, -1 if a < b and +1 if a > b.
+func (opts NaturalOptions) Compare(a, b string) int {
+	zeros := 0
+	for a != "" && b != "" {
+		ra, na := utf8.DecodeRuneInString(a)
+		rb, nb := utf8.DecodeRuneInString(b)
+
+		if opts.isDigit(ra) && opts.isDigit(rb) {
+			da, restA := opts.digitRun(a)
+			db, restB := opts.digitRun(b)
+			if c := opts.compareNumbers(da, db); c != 0 {
+				return c
+			}
+			// Remember the first leading zero difference as a tie-breaker
+			if zeros == 0 && !opts.IgnoreLeadingZeros {
+				zeros = compareInt(utf8.RuneCountInString(da), utf8.RuneCountInString(db))
+			}
+			a, b = restA, restB
+			continue
+		}
+
+		if opts.IgnoreCase {
+			ra, rb = foldRune(ra), foldRune(rb)
+		}
+		if ra != rb {
+			return compareInt(int(ra), int(rb))
+		}
+		a, b = a[na:], b[nb:]
+	}
+
+	switch {
+	case a == "" && b != "":
+		return -1
+	case a != "" && b == "":
+		return 1
+	}
+	return zeros
+}
+
+// Less reports whether a sorts before b in natural order using opts.
+func (opts NaturalOptions) Less(a, b string) bool {
+	return opts.Compare(a, b) < 0
+}
+
+// Sort sorts the Slice s in place in natural order using opts, keeping
+// the original order of elements which compare equal.
+func (opts NaturalOptions) Sort(s *Slice.Slice[String]) {
+	s.SortStableFunc(func(a, b String) int {
+		return opts.Compare(a.Value(), b.Value())
+	})
+}
+
+// isDigit reports whether r starts or continues a numeric run
+func (opts NaturalOptions) isDigit(r rune) bool {
+	if opts.UnicodeDigits {
+		return unicode.IsDigit(r)
+	}
+	return '0' <= r && r <= '9'
+}
+
+// digitRun splits s into its leading run of digits and the remainder
+func (opts NaturalOptions) digitRun(s string) (digits, rest string) {
+	end := strings.IndexFunc(s, func(r rune) bool { return !opts.isDigit(r) })
+	if end < 0 {
+		return s, ""
+	}
+	return s[:end], s[end:]
+}
+
+// compareNumbers compares two runs of digits by their numeric value without
+// converting them to integers, so arbitrarily long runs cannot overflow
+func (opts NaturalOptions) compareNumbers(a, b string) int {
+	a = strings.TrimLeftFunc(a, isZeroDigit)
+	b = strings.TrimLeftFunc(b, isZeroDigit)
+
+	if c := compareInt(utf8.RuneCountInString(a), utf8.RuneCountInString(b)); c != 0 {
+		return c
+	}
+	for a != "" {
+		ra, na := utf8.DecodeRuneInString(a)
+		rb, nb := utf8.DecodeRuneInString(b)
+		if c := compareInt(digitValue(ra), digitValue(rb)); c != 0 {
+			return c
+		}
+		a, b = a[na:], b[nb:]
+	}
+	return 0
+}
+
+// digitValue returns the numeric value of the decimal digit r. Unicode
+// decimal digits are always encoded as contiguous runs of ten code points
+// starting at zero, so the value is the offset from the start of the run.
+func digitValue(r rune) int {
+	if '0' <= r && r <= '9' {
+		return int(r - '0')
+	}
+	start := r
+	for unicode.IsDigit(start - 1) {
+		start--
+	}
+	return int(r-start) % 10
+}
+
+// isZeroDigit reports whether r is a decimal digit with the value zero
+func isZeroDigit(r rune) bool {
+	return unicode.IsDigit(r) && digitValue(r) == 0
+}
+
+// foldRune maps r to a canonical case so that runes which are equal under
+// simple case-folding compare equal
+func foldRune(r rune) rune {
+	return unicode.ToLower(unicode.ToUpper(r))
+}
+
+// compareInt returns -1, 0 or +1 depending on whether a is less than,
+// equal to or greater than b
+func compareInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// CompareVersions compares two version strings following Semantic Versioning
+// precedence. An optional leading "v" is ignored, missing minor or patch
+// numbers count as zero and build metadata after "+" does not affect the
+// ordering. A version with a pre-release tag ("1.0.0-rc.1") sorts before
+// the same version without one. Components which are not plain numbers are
+// compared in natural order. The result is 0 if a == b, -1 if a < b and
+// +1 if a > b.
+func CompareVersions(a, b String) int {
+	coreA, preA := splitVersion(a.Value())
+	coreB, preB := splitVersion(b.Value())
+
+	partsA := strings.Split(coreA, ".")
+	partsB := strings.Split(coreB, ".")
+	for i := range max(len(partsA), len(partsB), 3) {
+		pa, pb := "0", "0"
+		if i < len(partsA) && partsA[i] != "" {
+			pa = partsA[i]
+		}
+		if i < len(partsB) && partsB[i] != "" {
+			pb = partsB[i]
+		}
+		if c := (NaturalOptions{IgnoreLeadingZeros: true}).Compare(pa, pb); c != 0 {
+			return c
+		}
+	}
+
+	switch {
+	case preA == "" && preB == "":
+		return 0
+	case preA == "":
+		return 1
+	case preB == "":
+		return -1
+	}
+	return comparePreRelease(preA, preB)
+}
+
+// splitVersion returns the dotted core and the pre-release tag of version
+func splitVersion(version string) (core, pre string) {
+	version = strings.TrimSpace(version)
+	version = strings.TrimPrefix(strings.TrimPrefix(version, "v"), "V")
+	version, _, _ = strings.Cut(version, "+")
+	core, pre, _ = strings.Cut(version, "-")
+	return core, pre
+}
+
+// comparePreRelease compares two pre-release tags identifier by identifier.
+// Numeric identifiers compare numerically and sort before alphanumeric ones,
+// which compare in ASCII order. A shorter tag sorts first when all of its
+// identifiers equal the corresponding ones of the longer tag.
+func comparePreRelease(a, b string) int {
+	idsA := strings.Split(a, ".")
+	idsB := strings.Split(b, ".")
+	for i := range min(len(idsA), len(idsB)) {
+		x, y := idsA[i], idsB[i]
+		numX, numY := isNumeric(x), isNumeric(y)
+		switch {
+		case numX && numY:
+			if c := (NaturalOptions{IgnoreLeadingZeros: true}).compareNumbers(x, y); c != 0 {
+				return c
+			}
+		case numX:
+			return -1
+		case numY:
+			return 1
+		default:
+			if c := strings.Compare(x, y); c != 0 {
+				return c
+			}
+		}
+	}
+	return compareInt(len(idsA), len(idsB))
+}
+
+// isNumeric reports whether s is a non-empty run of ASCII digits
+func isNumeric(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,130 @@
+package String
+
+import (
+	crand "crypto/rand"
+	"encoding/binary"
+	"math/rand/v2"
+	"strings"
+)
+
+// Predefined character sets for [Random] and [Generator.Random]
+const (
+	CharsetDigits  = "0123456789"
+	CharsetLower   = "abcdefghijklmnopqrstuvwxyz"
+	CharsetUpper   = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	CharsetLetters = CharsetUpper + CharsetLower
+	CharsetAlnum   = CharsetDigits + CharsetLetters
+	CharsetHex     = "0123456789abcdef"
+	// CharsetBase58 is the Bitcoin base58 alphabet, which leaves out 0, O, I and l
+	CharsetBase58 = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+	// CharsetURLSafe is the base64url alphabet, safe in URLs and file names
+	CharsetURLSafe = CharsetAlnum + "-_"
+	// CharsetUnambiguous leaves out characters which are easily confused
+	// when read or typed, such as 0 and O or 1, l and I
+	CharsetUnambiguous = "23456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghjkmnpqrstuvwxyz"
+)
+
+// Generator produces random Strings from a source of random numbers.
+// Generators created with [NewSeededGenerator] are not safe for concurrent use.
+type Generator struct {
+	rng *rand.Rand
+}
+
+// cryptoSource is a [rand.Source] reading from crypto/rand
+type cryptoSource struct{}
+
+// Uint64 implements [rand.Source]
+func (cryptoSource) Uint64() uint64 {
+	var b [8]byte
+	crand.Read(b[:])
+	return binary.LittleEndian.Uint64(b[:])
+}
+
+// NewGenerator returns a [Generator] drawing from the cryptographically
+// secure random number generator of crypto/rand. It is safe for
+// concurrent use.
+func NewGenerator() *Generator {
+	return &Generator{rng: rand.New(cryptoSource{})}
+}
+
+// NewSeededGenerator returns a deterministic [Generator] which produces the
+// same sequence of Strings for the same seed, for reproducible tests and
+// fixtures. It must not be used for secrets.
+func NewSeededGenerator(seed uint64) *Generator {
+	return &Generator{rng: rand.New(rand.NewPCG(seed, seed^0x9e3779b97f4a7c15))}
+}
+
+// Random returns a String of n characters drawn uniformly from charset.
+// It panics if charset is empty or n is negative.
+func (g *Generator) Random(n int, charset string) String {
+	chars := []rune(charset)
+	if len(chars) == 0 {
+		panic("String: Random with empty charset")
+	}
+	if n < 0 {
+		panic("String: Random with negative length")
+	}
+
+	var buf strings.Builder
+	buf.Grow(n)
+	for range n {
+		// IntN is unbiased, so every character is equally likely
+		buf.WriteRune(chars[g.rng.IntN(len(chars))])
+	}
+	return New(buf.String())
+}
+
+// patternCharsets maps the placeholders of Pattern to their character sets
+var patternCharsets = map[rune]string{
+	'A': CharsetUpper,
+	'a': CharsetLower,
+	'9': CharsetDigits,
+	'x': CharsetHex,
+	'X': strings.ToUpper(CharsetHex),
+	'*': CharsetAlnum,
+	'?': CharsetUnambiguous,
+}
+
+// Pattern returns a String following pattern, in which each placeholder is
+// replaced by a random character of its class:
+//
+//	A	upper case letter
+//	a	lower case letter
+//	9	digit
+//	x	lower case hexadecimal digit
+//	X	upper case hexadecimal digit
+//	*	letter or digit
+//	?	character of CharsetUnambiguous
+//
+// A backslash makes the following character literal, and every other
+// character is copied unchanged, so "AAA-999-xxx" may give "QZD-306-e1b".
+func (g *Generator) Pattern(pattern string) String {
+	var buf strings.Builder
+	escaped := false
+	for _, r := range pattern {
+		if charset, ok := patternCharsets[r]; ok && !escaped {
+			buf.WriteString(g.Random(1, charset).Value())
+			continue
+		}
+		if r == '\\' && !escaped {
+			escaped = true
+			continue
+		}
+		buf.WriteRune(r)
+		escaped = false
+	}
+	return New(buf.String())
+}
+
+// Random returns a String of n characters drawn uniformly from charset using
+// crypto/rand, suitable for tokens and passwords. It panics if charset is
+// empty or n is negative.
+func Random(n int, charset string) String {
+	return NewGenerator().Random(n, charset)
+}
+
+// Pattern returns a random String following pattern using crypto/rand,
+// as described in [Generator.Pattern].
+func Pattern(pattern string) String {
+	return NewGenerator().Pattern(pattern)
+}
@@ -0,0 +1,309 @@
+package String
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/harishtpj/klassy/Slice"
+)
+
+// Mask returns self with every character except the first keepStart and the
+// last keepEnd replaced by mask. If self is too short to keep that many
+// characters and still hide something, every character is masked.
+func (self String) Mask(keepStart, keepEnd int, mask rune) String {
+	runes := []rune(self.Value())
+	keepStart, keepEnd = max(keepStart, 0), max(keepEnd, 0)
+	if keepStart+keepEnd >= len(runes) {
+		keepStart, keepEnd = 0, 0
+	}
+	for i := keepStart; i < len(runes)-keepEnd; i++ {
+		runes[i] = mask
+	}
+	return New(string(runes))
+}
+
+// MaskEmail masks the local part of an e-mail address except its first
+// character, so "john.doe@example.com" becomes "j*******@example.com".
+// Text without an '@' is masked completely.
+func (self String) MaskEmail() String {
+	local, domain, ok := strings.Cut(self.Value(), "@")
+	if !ok {
+		return self.Mask(0, 0, '*')
+	}
+	return New(local).Mask(1, 0, '*') + "@" + New(domain)
+}
+
+// MaskCard masks every digit of a payment card number except the last four,
+// keeping spaces and hyphens, so "4111 1111 1111 1111" becomes
+// "**** **** **** 1111".
+func (self String) MaskCard() String {
+	s := self.Value()
+	digits := 0
+	for i := 0; i < len(s); i++ {
+		if isDigitByte(s[i]) {
+			digits++
+		}
+	}
+
+	var buf strings.Builder
+	buf.Grow(len(s))
+	seen := 0
+	for _, r := range s {
+		if '0' <= r && r <= '9' {
+			seen++
+			if seen <= digits-4 {
+				r = '*'
+			}
+		}
+		buf.WriteRune(r)
+	}
+	return New(buf.String())
+}
+
+// Detector finds one kind of sensitive data for a [Redactor]. Find returns
+// the spans of text to redact.
+type Detector struct {
+	Name string
+	Find func(text String) Slice.Slice[Span]
+}
+
+// NewDetector returns a [Detector] finding the matches of pattern for which
+// valid returns true; a nil valid accepts every match. If pattern has a
+// capturing group, only the text of the first group is redacted, so
+// `(?i)password=(\S+)` keeps "password=" visible. It panics if pattern
+// does not compile.
+func NewDetector(name, pattern string, valid func(String) bool) Detector {
+	re := regexp.MustCompile(pattern)
+	return Detector{
+		Name: name,
+		Find: func(text String) Slice.Slice[Span] {
+			s := text.Value()
+			spans := Slice.New([]Span{})
+			for _, loc := range re.FindAllStringSubmatchIndex(s, -1) {
+				start, end := loc[0], loc[1]
+				if len(loc) > 2 {
+					if loc[2] < 0 {
+						continue
+					}
+					start, end = loc[2], loc[3]
+				}
+				if start == end || valid != nil && !valid(New(s[start:end])) {
+					continue
+				}
+				spans.Push(Span{Text: New(s[start:end]), Start: start, End: end})
+			}
+			return spans
+		},
+	}
+}
+
+// DefaultDetectors returns the detectors used by [NewRedactor], in the
+// order they are registered: JWTs, bearer tokens, AWS access keys and
+// secret keys, API keys, Luhn-valid payment card numbers and e-mail
+// addresses.
+func DefaultDetectors() []Detector {
+	return []Detector{
+		NewDetector("jwt", `\beyJ[A-Za-z0-9_-]+\.eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`, nil),
+		NewDetector("bearer", `(?i)\bauthorization["']?\s*[:=]\s*["']?bearer\s+([A-Za-z0-9\-._~+/]+=*)`, nil),
+		NewDetector("bearer", `(?i)\bbearer\s+([A-Za-z0-9\-._~+/]{16,}=*)`, isTokenShaped),
+		NewDetector("aws-access-key", `\b(?:AKIA|ASIA|AGPA|AIDA|AROA)[0-9A-Z]{16}\b`, nil),
+		NewDetector("aws-secret-key", `(?i)aws_?secret_?(?:access_?)?key["']?\s*[:=]\s*["']?([A-Za-z0-9/+=]{40})\b`, nil),
+		NewDetector("api-key", `\b(?:sk|pk|rk)_(?:live|test)_[0-9A-Za-z]{16,}\b|\bgh[pousr]_[A-Za-z0-9]{36,}\b|\bxox[abposr]-[A-Za-z0-9-]{10,}`, nil),
+		NewDetector("api-key", `(?i)\b(?:api[_-]?key|api[_-]?secret|access[_-]?token|auth[_-]?token|client[_-]?secret|secret[_-]?key)["']?\s*[:=]\s*["']?([A-Za-z0-9_\-./+=]{16,})`, nil),
+		{Name: "card", Find: cardSpans},
+		{Name: "email", Find: redactEmailSpans},
+	}
+}
+
+// isTokenShaped reports whether s looks like a token rather than a word:
+// outside an Authorization header "bearer" is an ordinary English word, so
+// what follows it is only taken for a token if it mixes letters and digits
+func isTokenShaped(s String) bool {
+	return strings.ContainsAny(s.Value(), "0123456789") &&
+		strings.IndexFunc(s.Value(), func(r rune) bool { return 'a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' }) >= 0
+}
+
+// cardSpans finds payment card numbers in text. Runs of digit groups joined
+// by single spaces or hyphens are split at group boundaries into windows of
+// 12 to 19 digits, and the leftmost-longest windows passing the Luhn
+// checksum are kept, so a card number followed by a CVV or preceded by
+// another number is still found. Time is linear in the length of the run.
+func cardSpans(text String) Slice.Slice[Span] {
+	s := text.Value()
+	spans := Slice.New([]Span{})
+	type group struct{ start, end int }
+	var run []group
+
+	flush := func() {
+		for a := 0; a < len(run); {
+			// Windows are at most 19 digits, so each start tries only a few
+			// ends, keeping the longest which passes the checksum
+			best := -1
+			var digits []byte
+			for b := a; b < len(run); b++ {
+				if digits = append(digits, s[run[b].start:run[b].end]...); len(digits) > 19 {
+					break
+				}
+				if len(digits) >= 12 && luhnValid(string(digits)) {
+					best = b
+				}
+			}
+			if best < 0 {
+				a++
+				continue
+			}
+			start, end := run[a].start, run[best].end
+			spans.Push(Span{Text: New(s[start:end]), Start: start, End: end})
+			a = best + 1
+		}
+		run = run[:0]
+	}
+
+	for i := 0; i < len(s); {
+		if !isDigitByte(s[i]) {
+			i++
+			continue
+		}
+		j := i
+		for j < len(s) && isDigitByte(s[j]) {
+			j++
+		}
+		run = append(run, group{i, j})
+		if j+1 < len(s) && (s[j] == ' ' || s[j] == '-') && isDigitByte(s[j+1]) {
+			i = j + 1
+			continue
+		}
+		flush()
+		i = j
+	}
+	return spans
+}
+
+// redactEmailSpans finds e-mail addresses for the redactor. In logs an
+// address often follows a key and '=', as in "user=john@example.com", so the
+// local part is taken to start after its last '='; that keeps the key
+// visible and still hides the address.
+func redactEmailSpans(text String) Slice.Slice[Span] {
+	spans := text.EmailSpans()
+	for i, sp := range spans.Items {
+		local, _, _ := strings.Cut(sp.Text.Value(), "@")
+		if eq := strings.LastIndexByte(local, '='); eq >= 0 && eq < len(local)-1 {
+			spans.Items[i] = Span{Text: sp.Text[eq+1:], Start: sp.Start + eq + 1, End: sp.End}
+		}
+	}
+	return spans
+}
+
+// Redaction records one piece of text replaced by a [Redactor]
+type Redaction struct {
+	Detector string // name of the detector which found the text
+	Start    int    // byte offset of the text in the original String
+	End      int    // byte offset just after the text in the original String
+	Original String
+}
+
+// Redactor replaces sensitive data found by its detectors. The zero value
+// has no detectors; use [NewRedactor] for the default ones. A Redactor is
+// safe for concurrent use once configured.
+type Redactor struct {
+	detectors []Detector
+	// Replacement returns the text replacing a redaction. If nil, the text
+	// is replaced by "[REDACTED:<detector>]".
+	Replacement func(r Redaction) String
+}
+
+// NewRedactor returns a [Redactor] using [DefaultDetectors]
+func NewRedactor() *Redactor {
+	return &Redactor{detectors: DefaultDetectors()}
+}
+
+// Add registers custom detectors after those already registered. Overlaps
+// with the matches of other detectors are resolved as described in
+// [Redactor.Find], so registration order only decides between matches
+// covering exactly the same text.
+func (r *Redactor) Add(detectors ...Detector) {
+	r.detectors = append(r.detectors, detectors...)
+}
+
+// Detectors returns the names of the registered detectors in the order they
+// were registered
+func (r *Redactor) Detectors() Slice.Slice[String] {
+	names := Slice.New([]String{})
+	for _, d := range r.detectors {
+		names.Push(New(d.Name))
+	}
+	return names
+}
+
+// Find returns what [Redactor.Redact] would redact in text, ordered by
+// position. Where matches overlap, the one starting first wins whichever
+// detector found it, then the longer one, and only then the one from the
+// detector registered first.
+func (r *Redactor) Find(text String) Slice.Slice[Redaction] {
+	found := Slice.New([]Redaction{})
+	for _, d := range r.detectors {
+		for _, sp := range d.Find(text).Items {
+			found.Push(Redaction{Detector: d.Name, Start: sp.Start, End: sp.End, Original: sp.Text})
+		}
+	}
+	found.SortStableFunc(func(a, b Redaction) int {
+		if a.Start != b.Start {
+			return a.Start - b.Start
+		}
+		return b.End - a.End
+	})
+
+	kept := Slice.New([]Redaction{})
+	end := 0
+	for _, red := range found.Items {
+		if red.Start >= end {
+			kept.Push(red)
+			end = red.End
+		}
+	}
+	return kept
+}
+
+// Redact returns text with the sensitive data found by the detectors replaced
+func (r *Redactor) Redact(text String) String {
+	redacted, _ := r.RedactReport(text)
+	return redacted
+}
+
+// RedactReport returns text with the sensitive data replaced, together with
+// a report of what was redacted and where. The offsets in the report refer
+// to the original text.
+func (r *Redactor) RedactReport(text String) (String, Slice.Slice[Redaction]) {
+	report := r.Find(text)
+	if report.Length() == 0 {
+		return text, report
+	}
+
+	s := text.Value()
+	var buf strings.Builder
+	last := 0
+	for _, red := range report.Items {
+		buf.WriteString(s[last:red.Start])
+		buf.WriteString(r.replacement(red).Value())
+		last = red.End
+	}
+	buf.WriteString(s[last:])
+	return New(buf.String()), report
+}
+
+// replacement returns the text replacing red
+func (r *Redactor) replacement(red Redaction) String {
+	if r.Replacement != nil {
+		return r.Replacement(red)
+	}
+	return New("[REDACTED:" + red.Detector + "]")
+}
+
+// MaskAll returns a replacement function for [Redactor.Replacement] which
+// masks every character of the redacted text with mask, keeping its length
+func MaskAll(mask rune) func(Redaction) String {
+	return func(red Redaction) String {
+		return New(strings.Repeat(string(mask), utf8.RuneCountInString(red.Original.Value())))
+	}
+}
@@ -0,0 +1,432 @@
+package String
+
+import (
+	"io"
+	"iter"
+	"strings"
+	"unicode/utf8"
+)
+
+// ropeLeafSize is the largest number of bytes kept in a single leaf
+const ropeLeafSize = 1024
+
+// Rope is an immutable string stored as a balanced binary tree of chunks,
+// for efficient editing of very large texts. Insert, Delete, Slice, Concat
+// and the line/column conversions take O(log n) time. Every edit returns a
+// new Rope which shares all unchanged chunks with the original, so keeping
+// old versions around as snapshots is cheap. Offsets are in bytes, like
+// those of String.
+//
+// The zero value is an empty Rope ready to use.
+type Rope struct {
+	root *ropeNode
+}
+
+// ropeNode is a leaf holding text or an inner node joining two subtrees
+type ropeNode struct {
+	left, right *ropeNode
+	text        string // contents of a leaf
+	length      int    // number of bytes in the subtree
+	lines       int    // number of '\n' in the subtree
+	height      int    // 0 for leaves
+}
+
+// NewRope returns a Rope holding s
+func NewRope(s string) Rope {
+	return Rope{root: buildRope(s)}
+}
+
+// ToRope returns a [Rope] holding self
+func (self String) ToRope() Rope {
+	return NewRope(self.Value())
+}
+
+// buildRope builds a balanced tree holding s
+func buildRope(s string) *ropeNode {
+	if len(s) <= ropeLeafSize {
+		return ropeLeaf(s)
+	}
+	// Keep multi-byte characters within a single leaf where possible
+	mid := len(s) / 2
+	for i := 0; i < utf8.UTFMax && !utf8.RuneStart(s[mid]); i++ {
+		mid--
+	}
+	return ropeConcat(buildRope(s[:mid]), buildRope(s[mid:]))
+}
+
+// ropeLeaf returns a leaf holding s, or nil if s is empty
+func ropeLeaf(s string) *ropeNode {
+	if s == "" {
+		return nil
+	}
+	return &ropeNode{text: s, length: len(s), lines: strings.Count(s, "\n")}
+}
+
+// ropeConcat returns an inner node with the given children without rebalancing
+func ropeConcat(l, r *ropeNode) *ropeNode {
+	return &ropeNode{
+		left:   l,
+		right:  r,
+		length: l.length + r.length,
+		lines:  l.lines + r.lines,
+		height: max(l.height, r.height) + 1,
+	}
+}
+
+// isLeaf reports whether n is a leaf
+func (n *ropeNode) isLeaf() bool {
+	return n.left == nil
+}
+
+// ropeHeight returns the height of n, with -1 for the empty tree
+func ropeHeight(n *ropeNode) int {
+	if n == nil {
+		return -1
+	}
+	return n.height
+}
+
+// ropeJoin concatenates two balanced trees into a balanced tree
+func ropeJoin(l, r *ropeNode) *ropeNode {
+	switch {
+	case l == nil:
+		return r
+	case r == nil:
+		return l
+	case l.isLeaf() && r.isLeaf() && l.length+r.length <= ropeLeafSize:
+		return ropeLeaf(l.text + r.text)
+	case l.height > r.height+1:
+		return ropeBalance(ropeConcat(l.left, ropeJoin(l.right, r)))
+	case r.height > l.height+1:
+		return ropeBalance(ropeConcat(ropeJoin(l, r.left), r.right))
+	}
+	return ropeConcat(l, r)
+}
+
+// ropeBalance restores the height invariant of n with AVL rotations
+func ropeBalance(n *ropeNode) *ropeNode {
+	switch {
+	case ropeHeight(n.left) > ropeHeight(n.right)+1:
+		l := n.left
+		if ropeHeight(l.left) < ropeHeight(l.right) {
+			l = ropeRotateLeft(l)
+		}
+		return ropeRotateRight(ropeConcat(l, n.right))
+	case ropeHeight(n.right) > ropeHeight(n.left)+1:
+		r := n.right
+		if ropeHeight(r.right) < ropeHeight(r.left) {
+			r = ropeRotateRight(r)
+		}
+		return ropeRotateLeft(ropeConcat(n.left, r))
+	}
+	return n
+}
+
+// ropeRotateLeft lifts the right child of n
+func ropeRotateLeft(n *ropeNode) *ropeNode {
+	r := n.right
+	return ropeConcat(ropeConcat(n.left, r.left), r.right)
+}
+
+// ropeRotateRight lifts the left child of n
+func ropeRotateRight(n *ropeNode) *ropeNode {
+	l := n.left
+	return ropeConcat(l.left, ropeConcat(l.right, n.right))
+}
+
+// ropeSplit splits n into the trees holding its first i bytes and the rest
+func ropeSplit(n *ropeNode, i int) (*ropeNode, *ropeNode) {
+	switch {
+	case n == nil:
+		return nil, nil
+	case i <= 0:
+		return nil, n
+	case i >= n.length:
+		return n, nil
+	case n.isLeaf():
+		return ropeLeaf(n.text[:i]), ropeLeaf(n.text[i:])
+	case i < n.left.length:
+		a, b := ropeSplit(n.left, i)
+		return a, ropeJoin(b, n.right)
+	case i > n.left.length:
+		a, b := ropeSplit(n.right, i-n.left.length)
+		return ropeJoin(n.left, a), b
+	}
+	return n.left, n.right
+}
+
+// checkRange panics if start and end do not form a valid range of self
+func (self Rope) checkRange(start, end int) {
+	if start < 0 || end < start || end > self.Length() {
+		panic("String: Rope range out of bounds")
+	}
+}
+
+// Length returns the number of bytes in self
+func (self Rope) Length() int {
+	if self.root == nil {
+		return 0
+	}
+	return self.root.length
+}
+
+// LineCount returns the number of lines in self. A final line without a
+// terminating newline is counted, and an empty Rope has one empty line.
+func (self Rope) LineCount() int {
+	if self.root == nil {
+		return 1
+	}
+	return self.root.lines + 1
+}
+
+// String returns the contents of self as a native string
+func (self Rope) String() string {
+	var buf strings.Builder
+	buf.Grow(self.Length())
+	for chunk := range self.Chunks() {
+		buf.WriteString(chunk)
+	}
+	return buf.String()
+}
+
+// ToString returns the contents of self as a String
+func (self Rope) ToString() String {
+	return New(self.String())
+}
+
+// Concat returns a Rope holding self followed by other
+func (self Rope) Concat(other Rope) Rope {
+	return Rope{root: ropeJoin(self.root, other.root)}
+}
+
+// Append returns a Rope holding self followed by s
+func (self Rope) Append(s string) Rope {
+	return Rope{root: ropeJoin(self.root, buildRope(s))}
+}
+
+// Insert returns a Rope with s inserted at byte offset at of self.
+// It panics if at is out of range.
+func (self Rope) Insert(at int, s string) Rope {
+	self.checkRange(at, at)
+	a, b := ropeSplit(self.root, at)
+	return Rope{root: ropeJoin(ropeJoin(a, buildRope(s)), b)}
+}
+
+// Delete returns a Rope with the bytes self[start:end] removed.
+// It panics if the range is out of bounds.
+func (self Rope) Delete(start, end int) Rope {
+	self.checkRange(start, end)
+	a, rest := ropeSplit(self.root, start)
+	_, b := ropeSplit(rest, end-start)
+	return Rope{root: ropeJoin(a, b)}
+}
+
+// Replace returns a Rope with the bytes self[start:end] replaced by s.
+// It panics if the range is out of bounds.
+func (self Rope) Replace(start, end int, s string) Rope {
+	return self.Delete(start, end).Insert(start, s)
+}
+
+// Slice returns a Rope holding the bytes self[start:end], sharing its
+// chunks with self. It panics if the range is out of bounds.
+func (self Rope) Slice(start, end int) Rope {
+	self.checkRange(start, end)
+	_, rest := ropeSplit(self.root, start)
+	mid, _ := ropeSplit(rest, end-start)
+	return Rope{root: mid}
+}
+
+// ByteAt returns the byte at offset i of self. It panics if i is out of range.
+func (self Rope) ByteAt(i int) byte {
+	if i < 0 || i >= self.Length() {
+		panic("String: Rope index out of range")
+	}
+	n := self.root
+	for !n.isLeaf() {
+		if i < n.left.length {
+			n = n.left
+		} else {
+			i -= n.left.length
+			n = n.right
+		}
+	}
+	return n.text[i]
+}
+
+// Index returns the byte offset of the first instance of substr in self,
+// or -1 if substr is not present in self.
+func (self Rope) Index(substr string) int {
+	if substr == "" {
+		return 0
+	}
+	// Carry the end of the previous chunks so that matches spanning
+	// chunk boundaries are found
+	var window string
+	offset := 0 // offset in self of the start of window
+	for chunk := range self.Chunks() {
+		window += chunk
+		if i := strings.Index(window, substr); i >= 0 {
+			return offset + i
+		}
+		if keep := len(substr) - 1; len(window) > keep {
+			offset += len(window) - keep
+			window = window[len(window)-keep:]
+		}
+	}
+	return -1
+}
+
+// OffsetToLineCol returns the zero-based line and the byte column within
+// that line of byte offset off of self. It panics if off is out of range.
+func (self Rope) OffsetToLineCol(off int) (line, col int) {
+	self.checkRange(off, off)
+	line = 0
+	n, rel := self.root, off
+	for n != nil && !n.isLeaf() {
+		if rel < n.left.length {
+			n = n.left
+		} else {
+			line += n.left.lines
+			rel -= n.left.length
+			n = n.right
+		}
+	}
+	if n != nil {
+		line += strings.Count(n.text[:rel], "\n")
+	}
+	return line, off - self.lineStart(line)
+}
+
+// LineColToOffset returns the byte offset of the zero-based line and byte
+// column col of self. It panics if line is out of range; col is clamped to
+// the end of the line.
+func (self Rope) LineColToOffset(line, col int) int {
+	if line < 0 || line >= self.LineCount() {
+		panic("String: Rope line out of range")
+	}
+	start := self.lineStart(line)
+	end := self.Length()
+	if line+1 < self.LineCount() {
+		end = self.lineStart(line+1) - 1
+	}
+	return start + min(max(col, 0), end-start)
+}
+
+// lineStart returns the offset of the first byte of the zero-based line
+func (self Rope) lineStart(line int) int {
+	if line == 0 {
+		return 0
+	}
+	// Find the offset just after the line-th newline
+	off := 0
+	n := self.root
+	for !n.isLeaf() {
+		if line <= n.left.lines {
+			n = n.left
+		} else {
+			line -= n.left.lines
+			off += n.left.length
+			n = n.right
+		}
+	}
+	text := n.text
+	for ; line > 0; line-- {
+		i := strings.IndexByte(text, '\n')
+		off += i + 1
+		text = text[i+1:]
+	}
+	return off
+}
+
+// Chunks returns an iterator over the chunks of text making up self, in
+// order. Chunks may split multi-byte characters.
+func (self Rope) Chunks() iter.Seq[string] {
+	return func(yield func(string) bool) {
+		var walk func(n *ropeNode) bool
+		walk = func(n *ropeNode) bool {
+			if n == nil {
+				return true
+			}
+			if n.isLeaf() {
+				return yield(n.text)
+			}
+			return walk(n.left) && walk(n.right)
+		}
+		walk(self.root)
+	}
+}
+
+// Lines returns an iterator over the newline-terminated lines of self, in
+// the same way as [String.Lines].
+func (self Rope) Lines() iter.Seq[String] {
+	return func(yield func(String) bool) {
+		var partial strings.Builder
+		for chunk := range self.Chunks() {
+			for chunk != "" {
+				i := strings.IndexByte(chunk, '\n')
+				if i < 0 {
+					partial.WriteString(chunk)
+					break
+				}
+				partial.WriteString(chunk[:i+1])
+				chunk = chunk[i+1:]
+				if !yield(New(partial.String())) {
+					return
+				}
+				partial.Reset()
+			}
+		}
+		if partial.Len() > 0 {
+			yield(New(partial.String()))
+		}
+	}
+}
+
+// WriteTo writes the contents of self to w, implementing [io.WriterTo].
+func (self Rope) WriteTo(w io.Writer) (int64, error) {
+	var total int64
+	for chunk := range self.Chunks() {
+		n, err := io.WriteString(w, chunk)
+		total += int64(n)
+		if err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+// RopeReader implements [io.Reader] over the contents of a Rope
+type RopeReader struct {
+	rope Rope
+	pos  int
+}
+
+// NewReader returns a [RopeReader] reading the contents of self. Since a
+// Rope is immutable, later edits do not affect what the reader returns.
+func (self Rope) NewReader() *RopeReader {
+	return &RopeReader{rope: self}
+}
+
+// Read implements [io.Reader]
+func (r *RopeReader) Read(p []byte) (int, error) {
+	if r.pos >= r.rope.Length() {
+		return 0, io.EOF
+	}
+	total := 0
+	for total < len(p) && r.pos < r.rope.Length() {
+		// Find the leaf holding the current position
+		n, rel := r.rope.root, r.pos
+		for !n.isLeaf() {
+			if rel < n.left.length {
+				n = n.left
+			} else {
+				rel -= n.left.length
+				n = n.right
+			}
+		}
+		copied := copy(p[total:], n.text[rel:])
+		total += copied
+		r.pos += copied
+	}
+	return total, nil
+}
@@ -0,0 +1,122 @@
+package String
+
+import (
+	"io"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// randomText returns n bytes of letters and newlines
+func randomText(rng *rand.Rand, n int) string {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = "abcde\n"[rng.Intn(6)]
+	}
+	return string(b)
+}
+
+// checkRope compares every query of r against the plain string want
+func checkRope(t *testing.T, r Rope, want string) {
+	t.Helper()
+	if got := r.String(); got != want {
+		t.Fatalf("rope holds %d bytes %q..., want %d bytes", len(got), got[:min(len(got), 20)], len(want))
+	}
+	if r.Length() != len(want) {
+		t.Fatalf("Length() = %d, want %d", r.Length(), len(want))
+	}
+	if got := r.LineCount(); got != strings.Count(want, "\n")+1 {
+		t.Fatalf("LineCount() = %d, want %d", got, strings.Count(want, "\n")+1)
+	}
+	if len(want) > 0 {
+		i := len(want) / 3
+		if r.ByteAt(i) != want[i] {
+			t.Fatalf("ByteAt(%d) = %q, want %q", i, r.ByteAt(i), want[i])
+		}
+	}
+	for _, off := range []int{0, len(want) / 2, len(want)} {
+		line, col := r.OffsetToLineCol(off)
+		wantLine := strings.Count(want[:off], "\n")
+		wantCol := off - (strings.LastIndexByte(want[:off], '\n') + 1)
+		if line != wantLine || col != wantCol {
+			t.Fatalf("OffsetToLineCol(%d) = %d, %d, want %d, %d", off, line, col, wantLine, wantCol)
+		}
+		if back := r.LineColToOffset(line, col); back != off {
+			t.Fatalf("LineColToOffset(%d, %d) = %d, want %d", line, col, back, off)
+		}
+	}
+	var lines []string
+	for line := range r.Lines() {
+		lines = append(lines, line.Value())
+	}
+	var wantLines []string
+	for line := range strings.Lines(want) {
+		wantLines = append(wantLines, line)
+	}
+	if strings.Join(lines, "|") != strings.Join(wantLines, "|") {
+		t.Fatalf("Lines() do not match the text")
+	}
+}
+
+func TestRopeAgainstString(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	want := randomText(rng, 5000)
+	r := NewRope(want)
+	checkRope(t, r, want)
+
+	for range 300 {
+		start := rng.Intn(len(want) + 1)
+		end := start + rng.Intn(len(want)-start+1)
+		text := randomText(rng, rng.Intn(3*ropeLeafSize))
+		switch rng.Intn(4) {
+		case 0:
+			r, want = r.Insert(start, text), want[:start]+text+want[start:]
+		case 1:
+			r, want = r.Delete(start, end), want[:start]+want[end:]
+		case 2:
+			r, want = r.Replace(start, end, text), want[:start]+text+want[end:]
+		case 3:
+			r, want = r.Append(text), want+text
+		}
+		checkRope(t, r, want)
+
+		if got := r.Slice(start/2, start).String(); got != want[start/2:start] {
+			t.Fatalf("Slice(%d, %d) = %q, want %q", start/2, start, got, want[start/2:start])
+		}
+		if start+10 <= len(want) {
+			substr := want[start : start+10]
+			if got := r.Index(substr); got != strings.Index(want, substr) {
+				t.Fatalf("Index(%q) = %d, want %d", substr, got, strings.Index(want, substr))
+			}
+		}
+	}
+}
+
+func TestRopeSnapshots(t *testing.T) {
+	v1 := NewRope("hello world")
+	v2 := v1.Insert(5, ",").Append("!")
+	if v1.String() != "hello world" || v2.String() != "hello, world!" {
+		t.Errorf("v1 = %q, v2 = %q", v1, v2)
+	}
+	if got := v1.Concat(v2).Length(); got != len("hello world")+len("hello, world!") {
+		t.Errorf("Concat length = %d", got)
+	}
+}
+
+func TestRopeReader(t *testing.T) {
+	text := randomText(rand.New(rand.NewSource(2)), 10*ropeLeafSize)
+	got, err := io.ReadAll(NewRope(text).NewReader())
+	if err != nil || string(got) != text {
+		t.Errorf("reading the rope gave %d bytes, %v", len(got), err)
+	}
+	var buf strings.Builder
+	if n, err := NewRope(text).WriteTo(&buf); err != nil || n != int64(len(text)) || buf.String() != text {
+		t.Errorf("WriteTo wrote %d bytes, %v", n, err)
+	}
+}
+
+func TestRopeZeroValue(t *testing.T) {
+	var r Rope
+	checkRope(t, r, "")
+	checkRope(t, r.Insert(0, "a\nb"), "a\nb")
+}
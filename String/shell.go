@@ -0,0 +1,180 @@
+package String
+
+import (
+	"fmt"
+	"iter"
+	"strings"
+
+	"github.com/harishtpj/klassy/Slice"
+)
+
+// ShellSplitError is returned when self cannot be split into shell words,
+// for example because of an unterminated quote. Offset is the byte offset
+// in the input at which the offending construct starts.
+type ShellSplitError struct {
+	Offset int
+	Reason string
+}
+
+// Error implements the error interface
+func (e *ShellSplitError) Error() string {
+	return fmt.Sprintf("shell split: %s at offset %d", e.Reason, e.Offset)
+}
+
+// ShellSplit splits self into words using the quoting rules of the POSIX
+// shell. Words are separated by unquoted blanks and newlines, text inside
+// single quotes is taken literally, inside double quotes a backslash only
+// escapes '$', '`', '"', '\' and newline, and elsewhere a backslash escapes
+// the following character. An unquoted '#' at the start of a word starts a
+// comment which runs to the end of the line. Unlike a shell, no expansion of
+// variables, globs or commands is performed.
+//
+// If self contains an unterminated quote or ends in an unescaped backslash,
+// a *[ShellSplitError] reporting the position is returned along with the
+// words read before it.
+func (self String) ShellSplit() (Slice.Slice[String], error) {
+	words := Slice.New([]String{})
+	for word, err := range self.ShellSplitSeq() {
+		if err != nil {
+			return words, err
+		}
+		words.Push(word)
+	}
+	return words, nil
+}
+
+// ShellSplitSeq returns an iterator over the words of self split with the
+// rules described in [ShellSplit], without constructing the slice. If an
+// error is found, it is yielded with an empty word and the iteration stops.
+func (self String) ShellSplitSeq() iter.Seq2[String, error] {
+	return func(yield func(String, error) bool) {
+		s := self.Value()
+		i := 0
+		for {
+			word, next, ok, err := nextShellWord(s, i)
+			if err != nil {
+				yield("", err)
+				return
+			}
+			if !ok {
+				return
+			}
+			if !yield(New(word), nil) {
+				return
+			}
+			i = next
+		}
+	}
+}
+
+// nextShellWord scans the word starting at or after offset i of s. It reports
+// ok == false when only blanks and comments remain.
+func nextShellWord(s string, i int) (word string, next int, ok bool, err error) {
+	i = skipShellBlanks(s, i)
+	if i >= len(s) {
+		return "", len(s), false, nil
+	}
+
+	var buf strings.Builder
+	for i < len(s) {
+		switch c := s[i]; c {
+		case ' ', '\t', '\n', '\r':
+			return buf.String(), i, true, nil
+		case '\\':
+			if i+1 >= len(s) {
+				return "", i, false, &ShellSplitError{Offset: i, Reason: "trailing backslash"}
+			}
+			if s[i+1] != '\n' {
+				buf.WriteByte(s[i+1])
+			}
+			i += 2
+		case '\'':
+			end := strings.IndexByte(s[i+1:], '\'')
+			if end < 0 {
+				return "", i, false, &ShellSplitError{Offset: i, Reason: "unterminated single quote"}
+			}
+			buf.WriteString(s[i+1 : i+1+end])
+			i += end + 2
+		case '"':
+			start := i
+			i++
+			for {
+				if i >= len(s) {
+					return "", start, false, &ShellSplitError{Offset: start, Reason: "unterminated double quote"}
+				}
+				if s[i] == '"' {
+					i++
+					break
+				}
+				if s[i] == '\\' && i+1 < len(s) && strings.IndexByte("$`\"\\\n", s[i+1]) >= 0 {
+					if s[i+1] != '\n' {
+						buf.WriteByte(s[i+1])
+					}
+					i += 2
+					continue
+				}
+				buf.WriteByte(s[i])
+				i++
+			}
+		default:
+			buf.WriteByte(c)
+			i++
+		}
+	}
+	return buf.String(), i, true, nil
+}
+
+// skipShellBlanks returns the offset of the first character at or after i
+// which is not a blank, a line continuation or part of a comment
+func skipShellBlanks(s string, i int) int {
+	for i < len(s) {
+		switch c := s[i]; {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '\\' && i+1 < len(s) && s[i+1] == '\n':
+			i += 2
+		case c == '#':
+			end := strings.IndexByte(s[i:], '\n')
+			if end < 0 {
+				return len(s)
+			}
+			i += end + 1
+		default:
+			return i
+		}
+	}
+	return i
+}
+
+// ShellQuote returns self quoted so that a POSIX shell, or [ShellSplit],
+// reads it back as a single word with the same value. Strings made up only
+// of characters with no special meaning to the shell are returned unchanged.
+func (self String) ShellQuote() String {
+	s := self.Value()
+	if s == "" {
+		return "''"
+	}
+	if strings.IndexFunc(s, isShellUnsafe) < 0 {
+		return self
+	}
+	return New("'" + strings.ReplaceAll(s, "'", `'"'"'`) + "'")
+}
+
+// ShellJoin quotes each element of words with [String.ShellQuote] and joins
+// them with single spaces. It is the inverse of [String.ShellSplit].
+func ShellJoin(words Slice.Slice[String]) String {
+	quoted := make([]string, 0, words.Length())
+	for word := range words.Values() {
+		quoted = append(quoted, word.ShellQuote().Value())
+	}
+	return New(strings.Join(quoted, " "))
+}
+
+// isShellUnsafe reports whether r must be quoted to be taken literally
+func isShellUnsafe(r rune) bool {
+	switch {
+	case 'a' <= r && r <= 'z', 'A' <= r && r <= 'Z', '0' <= r && r <= '9':
+		return false
+	}
+	return !strings.ContainsRune("@%+=:,./-_", r)
+}
@@ -0,0 +1,387 @@
+package String
+
+import (
+	"cmp"
+	"hash/fnv"
+	"math"
+	"strings"
+
+	"github.com/harishtpj/klassy/Slice"
+)
+
+// NGrams returns the word n-grams of self: each run of n consecutive words,
+// as returned by [String.Words], joined by a single space. Text with fewer
+// than n words has no n-grams. It panics if n is less than 1.
+func (self String) NGrams(n int) Slice.Slice[String] {
+	if n < 1 {
+		panic("String: NGrams with n < 1")
+	}
+	words := self.Words().Items
+	grams := Slice.New([]String{})
+	for i := 0; i+n <= len(words); i++ {
+		parts := make([]string, n)
+		for j := range parts {
+			parts[j] = words[i+j].Value()
+		}
+		grams.Push(New(strings.Join(parts, " ")))
+	}
+	return grams
+}
+
+// CharShingles returns the character k-shingles of self: every run of k
+// consecutive characters, in order and with repeats. Text shorter than k
+// characters is a single shingle, so short Strings can still be compared,
+// and the empty String has none. It panics if k is less than 1.
+func (self String) CharShingles(k int) Slice.Slice[String] {
+	if k < 1 {
+		panic("String: CharShingles with k < 1")
+	}
+	runes := []rune(self.Value())
+	shingles := Slice.New([]String{})
+	if len(runes) > 0 && len(runes) < k {
+		shingles.Push(self)
+	}
+	for i := 0; i+k <= len(runes); i++ {
+		shingles.Push(New(string(runes[i : i+k])))
+	}
+	return shingles
+}
+
+// Jaccard returns the Jaccard similarity of the sets of elements of a and b,
+// the size of their intersection divided by the size of their union, from
+// 0 for disjoint sets to 1 for equal ones. Two empty sets are equal.
+func Jaccard(a, b Slice.Slice[String]) float64 {
+	set := make(map[String]bool, a.Length())
+	for _, s := range a.Items {
+		set[s] = true
+	}
+	union, inter := len(set), 0
+	seen := make(map[String]bool, b.Length())
+	for _, s := range b.Items {
+		if seen[s] {
+			continue
+		}
+		seen[s] = true
+		if set[s] {
+			inter++
+		} else {
+			union++
+		}
+	}
+	if union == 0 {
+		return 1
+	}
+	return float64(inter) / float64(union)
+}
+
+// Cosine returns the cosine similarity of the term-frequency vectors of a
+// and b, which counts repeated elements, from 0 when they share no element
+// to 1 when they have the same proportions. It is 0 if either is empty.
+func Cosine(a, b Slice.Slice[String]) float64 {
+	return termFrequencies(a).Cosine(termFrequencies(b))
+}
+
+// termFrequencies returns the number of times each element occurs in terms
+func termFrequencies(terms Slice.Slice[String]) Vector {
+	v := make(Vector, terms.Length())
+	for _, t := range terms.Items {
+		v[t]++
+	}
+	return v
+}
+
+// Vector is a sparse vector of weights indexed by term
+type Vector map[String]float64
+
+// Dot returns the dot product of v and other
+func (v Vector) Dot(other Vector) float64 {
+	if len(other) < len(v) {
+		v, other = other, v
+	}
+	sum := 0.0
+	for term, w := range v {
+		sum += w * other[term]
+	}
+	return sum
+}
+
+// Norm returns the Euclidean length of v
+func (v Vector) Norm() float64 {
+	return math.Sqrt(v.Dot(v))
+}
+
+// Cosine returns the cosine of the angle between v and other, or 0 if
+// either is a zero vector
+func (v Vector) Cosine(other Vector) float64 {
+	norms := v.Norm() * other.Norm()
+	if norms == 0 {
+		return 0
+	}
+	return v.Dot(other) / norms
+}
+
+// Corpus is a collection of documents indexed for similarity search. Terms
+// are the words of the documents in lower case, weighted by TF-IDF: how
+// often a term occurs in a document, scaled down by how many documents it
+// occurs in, so rare terms count for more than common ones. A Corpus is
+// safe for concurrent use once built.
+type Corpus struct {
+	docs    Slice.Slice[String]
+	df      map[String]int // number of documents containing each term
+	vectors []Vector       // normalised TF-IDF vector of each document
+}
+
+// Ranked is a document of a [Corpus] with its similarity to a query
+type Ranked struct {
+	Index int // index of the document in the Corpus
+	Score float64
+}
+
+// DuplicatePair is a pair of documents of a [Corpus] found to be near
+// duplicates, with A < B
+type DuplicatePair struct {
+	A, B       int
+	Similarity float64 // the estimated Jaccard similarity of their shingles
+}
+
+// corpusTerms returns the terms of doc
+func corpusTerms(doc String) Slice.Slice[String] {
+	terms := Slice.New([]String{})
+	for word := range doc.WordsSeq() {
+		terms.Push(word.ToLower())
+	}
+	return terms
+}
+
+// NewCorpus builds a [Corpus] of docs, computing the TF-IDF vector of each
+func NewCorpus(docs Slice.Slice[String]) *Corpus {
+	c := &Corpus{docs: Slice.New(docs.Items), df: map[String]int{}}
+	counts := make([]Vector, docs.Length())
+	for i, doc := range docs.Items {
+		counts[i] = termFrequencies(corpusTerms(doc))
+		for term := range counts[i] {
+			c.df[term]++
+		}
+	}
+	c.vectors = make([]Vector, len(counts))
+	for i, tf := range counts {
+		c.vectors[i] = c.weigh(tf)
+	}
+	return c
+}
+
+// weigh returns the normalised TF-IDF vector for the term counts tf
+func (c *Corpus) weigh(tf Vector) Vector {
+	total := 0.0
+	for _, n := range tf {
+		total += n
+	}
+	v := make(Vector, len(tf))
+	for term, n := range tf {
+		v[term] = n / total * c.IDF(term)
+	}
+	if norm := v.Norm(); norm > 0 {
+		for term := range v {
+			v[term] /= norm
+		}
+	}
+	return v
+}
+
+// Len returns the number of documents in the corpus
+func (c *Corpus) Len() int {
+	return c.docs.Length()
+}
+
+// Document returns the document at index i
+func (c *Corpus) Document(i int) String {
+	return c.docs.At(i)
+}
+
+// IDF returns the smoothed inverse document frequency of term, in lower
+// case, ln((1+N)/(1+df))+1 for N documents of which df contain the term.
+// Terms found in no document have the highest weight.
+func (c *Corpus) IDF(term String) float64 {
+	n := float64(c.docs.Length())
+	return math.Log((1+n)/(1+float64(c.df[term]))) + 1
+}
+
+// Vector returns the normalised TF-IDF vector of the document at index i.
+// It must not be modified.
+func (c *Corpus) Vector(i int) Vector {
+	return c.vectors[i]
+}
+
+// QueryVector returns the normalised TF-IDF vector of text, weighted by the
+// document frequencies of the corpus
+func (c *Corpus) QueryVector(text String) Vector {
+	return c.weigh(termFrequencies(corpusTerms(text)))
+}
+
+// Similarity returns the cosine similarity of the TF-IDF vectors of the
+// documents at indexes i and j
+func (c *Corpus) Similarity(i, j int) float64 {
+	return c.vectors[i].Dot(c.vectors[j])
+}
+
+// Rank returns the k documents most similar to query by the cosine
+// similarity of their TF-IDF vectors, most similar first, leaving out
+// documents with no term in common with it. If k is 0 or less, every
+// matching document is returned.
+func (c *Corpus) Rank(query String, k int) Slice.Slice[Ranked] {
+	q := c.QueryVector(query)
+	ranked := Slice.New([]Ranked{})
+	for i, v := range c.vectors {
+		if score := q.Dot(v); score > 0 {
+			ranked.Push(Ranked{Index: i, Score: score})
+		}
+	}
+	ranked.SortStableFunc(func(a, b Ranked) int { return cmp.Compare(b.Score, a.Score) })
+	if k > 0 && ranked.Length() > k {
+		ranked.Items = ranked.Items[:k]
+	}
+	return ranked
+}
+
+// Signature is a MinHash signature of a set, whose agreement with another
+// signature estimates the Jaccard similarity of the two sets
+type Signature []uint64
+
+// Jaccard returns the fraction of positions at which s and other agree,
+// which estimates the Jaccard similarity of the sets they were computed
+// from. Signatures of different lengths give 0.
+func (s Signature) Jaccard(other Signature) float64 {
+	if len(s) != len(other) || len(s) == 0 {
+		return 0
+	}
+	same := 0
+	for i := range s {
+		if s[i] == other[i] {
+			same++
+		}
+	}
+	return float64(same) / float64(len(s))
+}
+
+// MinHasher computes [Signature]s with a fixed family of hash functions.
+// Signatures are deterministic, so they can be stored and compared across
+// runs when computed with the same number of hashes.
+type MinHasher struct {
+	seeds []uint64
+}
+
+// NewMinHasher returns a [MinHasher] computing signatures of numHashes
+// values. More hashes estimate similarity more precisely; the error is
+// about 1/sqrt(numHashes). It panics if numHashes is less than 1.
+func NewMinHasher(numHashes int) *MinHasher {
+	if numHashes < 1 {
+		panic("String: NewMinHasher with numHashes < 1")
+	}
+	seeds := make([]uint64, numHashes)
+	state := uint64(0x2545F4914F6CDD1D)
+	for i := range seeds {
+		state += 0x9E3779B97F4A7C15
+		seeds[i] = mix64(state)
+	}
+	return &MinHasher{seeds: seeds}
+}
+
+// mix64 is the finalizer of SplitMix64, which scrambles the bits of x
+func mix64(x uint64) uint64 {
+	x = (x ^ x>>30) * 0xBF58476D1CE4E5B9
+	x = (x ^ x>>27) * 0x94D049BB133111EB
+	return x ^ x>>31
+}
+
+// Signature returns the MinHash signature of the set of elements of set.
+// The signature of an empty set is all ones, and agrees only with others
+// of empty sets.
+func (h *MinHasher) Signature(set Slice.Slice[String]) Signature {
+	sig := make(Signature, len(h.seeds))
+	for i := range sig {
+		sig[i] = math.MaxUint64
+	}
+	for _, s := range set.Items {
+		f := fnv.New64a()
+		f.Write([]byte(s.Value()))
+		base := f.Sum64()
+		for i, seed := range h.seeds {
+			if v := mix64(base ^ seed); v < sig[i] {
+				sig[i] = v
+			}
+		}
+	}
+	return sig
+}
+
+// Signatures returns the MinHash signatures of the character k-shingles of
+// each document, as computed by h
+func (c *Corpus) Signatures(h *MinHasher, k int) []Signature {
+	sigs := make([]Signature, c.docs.Length())
+	for i, doc := range c.docs.Items {
+		sigs[i] = h.Signature(doc.ToLower().CharShingles(k))
+	}
+	return sigs
+}
+
+// NearDuplicates returns the pairs of documents whose character k-shingles,
+// in lower case, have an estimated Jaccard similarity of at least
+// threshold, ordered by A then B. Candidate pairs are found by
+// locality-sensitive hashing of the MinHash signatures computed by h, so
+// large corpora are not compared pair by pair; a few pairs just above the
+// threshold may be missed.
+func (c *Corpus) NearDuplicates(h *MinHasher, k int, threshold float64) Slice.Slice[DuplicatePair] {
+	sigs := c.Signatures(h, k)
+	bands, rows := lshBands(len(h.seeds), threshold)
+
+	type pair struct{ a, b int }
+	candidates := map[pair]bool{}
+	for band := range bands {
+		buckets := map[string][]int{}
+		for i, sig := range sigs {
+			var key strings.Builder
+			for _, v := range sig[band*rows : (band+1)*rows] {
+				for shift := 0; shift < 64; shift += 8 {
+					key.WriteByte(byte(v >> shift))
+				}
+			}
+			buckets[key.String()] = append(buckets[key.String()], i)
+		}
+		for _, docs := range buckets {
+			for x := 0; x < len(docs); x++ {
+				for y := x + 1; y < len(docs); y++ {
+					candidates[pair{docs[x], docs[y]}] = true
+				}
+			}
+		}
+	}
+
+	pairs := Slice.New([]DuplicatePair{})
+	for p := range candidates {
+		if sim := sigs[p.a].Jaccard(sigs[p.b]); sim >= threshold {
+			pairs.Push(DuplicatePair{A: p.a, B: p.b, Similarity: sim})
+		}
+	}
+	pairs.SortFunc(func(x, y DuplicatePair) int {
+		return cmp.Or(cmp.Compare(x.A, y.A), cmp.Compare(x.B, y.B))
+	})
+	return pairs
+}
+
+// lshBands divides a signature of n values into bands of rows values so
+// that pairs of similarity threshold are likely to share a band: the
+// similarity at which the chance is one half, about (1/bands)^(1/rows), is
+// chosen a little below threshold
+func lshBands(n int, threshold float64) (bands, rows int) {
+	bands, rows = n, 1
+	for r := 1; r <= n; r++ {
+		if n%r != 0 {
+			continue
+		}
+		b := n / r
+		if math.Pow(1/float64(b), 1/float64(r)) <= threshold*0.85 {
+			bands, rows = b, r
+		}
+	}
+	return bands, rows
+}
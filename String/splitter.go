@@ -0,0 +1,207 @@
+package String
+
+import (
+	"iter"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/harishtpj/klassy/Slice"
+)
+
+// Splitter splits Strings on one or more separators, optionally treating
+// quoted text and bracket groups as regions which are never split. It is
+// configured by chaining methods, each returning a new Splitter, in the
+// style of Guava's Splitter:
+//
+//	NewSplitter(",").Quotes(`"`, 0).TrimResults().OmitEmpty().Split(s)
+//
+// The zero value splits on nothing, so each String is a single piece.
+type Splitter struct {
+	seps      []string
+	quotes    string
+	escape    rune
+	brackets  [][2]rune
+	trim      bool
+	omitEmpty bool
+	limit     int
+}
+
+// NewSplitter returns a [Splitter] splitting on each of seps, which may be
+// several characters long. Where separators overlap, the longest one
+// matching at a position is used. Empty separators are ignored.
+func NewSplitter(seps ...string) Splitter {
+	var kept []string
+	for _, sep := range seps {
+		if sep != "" {
+			kept = append(kept, sep)
+		}
+	}
+	return Splitter{seps: kept}
+}
+
+// Quotes returns a copy of self which does not split inside text quoted by
+// any of the characters in quoteChars; a quote ends at the next instance of
+// the character which opened it, so a doubled quote as in CSV is kept
+// within the quoted text. If escape is not 0, the character following it
+// is never treated as a quote, bracket or separator. Quotes and escapes are
+// kept in the results.
+func (self Splitter) Quotes(quoteChars string, escape rune) Splitter {
+	self.quotes, self.escape = quoteChars, escape
+	return self
+}
+
+// Brackets returns a copy of self which does not split inside bracket
+// groups, given as pairs of opening and closing characters such as "()",
+// "[]" and "{}". Groups may be nested. It panics if a pair is not made of
+// exactly two characters.
+func (self Splitter) Brackets(pairs ...string) Splitter {
+	self.brackets = nil
+	for _, pair := range pairs {
+		runes := []rune(pair)
+		if len(runes) != 2 {
+			panic("String: Splitter bracket pair " + pair + " is not two characters")
+		}
+		self.brackets = append(self.brackets, [2]rune{runes[0], runes[1]})
+	}
+	return self
+}
+
+// TrimResults returns a copy of self which removes leading and trailing
+// white space from each piece
+func (self Splitter) TrimResults() Splitter {
+	self.trim = true
+	return self
+}
+
+// OmitEmpty returns a copy of self which leaves out empty pieces, after
+// trimming if [Splitter.TrimResults] is set
+func (self Splitter) OmitEmpty() Splitter {
+	self.omitEmpty = true
+	return self
+}
+
+// Limit returns a copy of self which returns at most n pieces; the last
+// piece holds the unsplit remainder of the text. A limit of 0 or less
+// means no limit.
+func (self Splitter) Limit(n int) Splitter {
+	self.limit = n
+	return self
+}
+
+// Split splits s into a Slice of pieces as configured
+func (self Splitter) Split(s String) Slice.Slice[String] {
+	pieces := Slice.New([]String{})
+	pieces.AppendSeq(self.SplitSeq(s))
+	return pieces
+}
+
+// SplitSeq returns an iterator over the pieces of s, as returned by
+// [Splitter.Split], without constructing the slice. The text is scanned
+// lazily, so stopping early does not scan the rest of s.
+func (self Splitter) SplitSeq(s String) iter.Seq[String] {
+	return func(yield func(String) bool) {
+		text := s.Value()
+		count := 0
+		emit := func(piece string) bool {
+			if self.trim {
+				piece = strings.TrimSpace(piece)
+			}
+			if piece == "" && self.omitEmpty {
+				return true
+			}
+			count++
+			return yield(New(piece))
+		}
+
+		start := 0
+		for start <= len(text) {
+			if self.limit > 0 && count == self.limit-1 {
+				emit(text[start:])
+				return
+			}
+			sepStart, sepEnd := self.nextSeparator(text, start)
+			if sepStart < 0 {
+				emit(text[start:])
+				return
+			}
+			if !emit(text[start:sepStart]) {
+				return
+			}
+			start = sepEnd
+		}
+	}
+}
+
+// nextSeparator returns the offsets of the first separator in text at or
+// after from which is outside quotes and bracket groups, or -1, -1
+func (self Splitter) nextSeparator(text string, from int) (start, end int) {
+	var quote rune
+	var open []rune // stack of closing brackets expected
+	for i := from; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		switch {
+		case self.escape != 0 && r == self.escape:
+			// Skip the escaped character as well
+			i += size
+			if i < len(text) {
+				_, next := utf8.DecodeRuneInString(text[i:])
+				i += next
+			}
+			continue
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case strings.ContainsRune(self.quotes, r):
+			quote = r
+		case len(open) > 0 && r == open[len(open)-1]:
+			open = open[:len(open)-1]
+		case self.opening(r) != 0:
+			open = append(open, self.opening(r))
+		case len(open) == 0:
+			if n := self.separatorAt(text[i:]); n > 0 {
+				return i, i + n
+			}
+		}
+		i += size
+	}
+	return -1, -1
+}
+
+// opening returns the closing bracket for r if r opens a bracket group, or 0
+func (self Splitter) opening(r rune) rune {
+	for _, pair := range self.brackets {
+		if pair[0] == r {
+			return pair[1]
+		}
+	}
+	return 0
+}
+
+// separatorAt returns the length of the longest separator at the start of
+// text, or 0 if there is none
+func (self Splitter) separatorAt(text string) int {
+	longest := 0
+	for _, sep := range self.seps {
+		if len(sep) > longest && strings.HasPrefix(text, sep) {
+			longest = len(sep)
+		}
+	}
+	return longest
+}
+
+// SplitQuoted slices self into all substrings separated by sep, without
+// splitting inside text quoted by any of the characters in quoteChars, as in
+// CSV. If escape is not 0, the character following it is never treated as a
+// quote or separator. Quotes and escapes are kept in the substrings.
+//
+//	New(`a,"b,c",d`).SplitQuoted(",", `"`, 0) // [a "b,c" d]
+func (self String) SplitQuoted(sep, quoteChars string, escape rune) Slice.Slice[String] {
+	return NewSplitter(sep).Quotes(quoteChars, escape).Split(self)
+}
+
+// SplitMulti slices self into all substrings separated by any of seps. Where
+// separators overlap, the longest one matching at a position is used.
+func (self String) SplitMulti(seps ...string) Slice.Slice[String] {
+	return NewSplitter(seps...).Split(self)
+}
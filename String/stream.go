@@ -0,0 +1,259 @@
+package String
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"iter"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// DefaultMaxTokenSize is the default limit on the length of a single line,
+// field or piece read by a [Stream]
+const DefaultMaxTokenSize = 1 << 20
+
+// Stream applies String operations to text read from an [io.Reader], holding
+// only the current line, field or piece in memory, so files far larger
+// than memory can be processed. A Stream reads its input once: use a single
+// one of its methods on it. Errors end the iteration and are reported by
+// [Stream.Err], as with [bufio.Scanner]:
+//
+//	st := FromReader(file)
+//	for line := range st.Lines() {
+//		...
+//	}
+//	if err := st.Err(); err != nil {
+//		...
+//	}
+type Stream struct {
+	r        io.Reader
+	maxToken int
+	err      error
+}
+
+// FromReader returns a [Stream] reading from r
+func FromReader(r io.Reader) *Stream {
+	return &Stream{r: r, maxToken: DefaultMaxTokenSize}
+}
+
+// MaxTokenSize sets the limit on the length in bytes of a single line,
+// field or piece, which defaults to [DefaultMaxTokenSize]. A longer one
+// stops the iteration with [bufio.ErrTooLong]. It returns st for chaining.
+func (st *Stream) MaxTokenSize(n int) *Stream {
+	st.maxToken = n
+	return st
+}
+
+// Err returns the first error met while reading, other than [io.EOF]
+func (st *Stream) Err() error {
+	return st.err
+}
+
+// setErr records err unless an error has already been recorded
+func (st *Stream) setErr(err error) {
+	if st.err == nil && err != nil && err != io.EOF {
+		st.err = err
+	}
+}
+
+// scan returns an iterator over the tokens of the input as split by split
+func (st *Stream) scan(split bufio.SplitFunc) iter.Seq[String] {
+	return func(yield func(String) bool) {
+		if st.err != nil {
+			return
+		}
+		sc := bufio.NewScanner(st.r)
+		sc.Buffer(make([]byte, 0, min(4096, st.maxToken)), st.maxToken)
+		sc.Split(split)
+		for sc.Scan() {
+			if !yield(New(sc.Text())) {
+				return
+			}
+		}
+		st.setErr(sc.Err())
+	}
+}
+
+// scanLines is a [bufio.SplitFunc] like [bufio.ScanLines] which keeps the
+// newline at the end of each line, as [String.Lines] does
+func scanLines(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if i := bytes.IndexByte(data, '\n'); i >= 0 {
+		return i + 1, data[:i+1], nil
+	}
+	if atEOF && len(data) > 0 {
+		return len(data), data, nil
+	}
+	return 0, nil, nil
+}
+
+// Lines returns an iterator over the newline-terminated lines of the input,
+// as [String.Lines] does for a String: the lines include their terminating
+// newlines, and the final line does not end in a newline if the input
+// does not.
+func (st *Stream) Lines() iter.Seq[String] {
+	return st.scan(scanLines)
+}
+
+// Fields returns an iterator over the fields of the input separated by runs
+// of white space, as defined by unicode.IsSpace, like [String.FieldsSeq].
+func (st *Stream) Fields() iter.Seq[String] {
+	return st.scan(bufio.ScanWords)
+}
+
+// SplitSeq returns an iterator over the pieces of the input separated by
+// sep, like [String.SplitSeq]. A separator split across reads of the
+// underlying reader is still found. If sep is empty, the input is split
+// after each UTF-8 sequence.
+func (st *Stream) SplitSeq(sep string) iter.Seq[String] {
+	if sep == "" {
+		return st.scan(bufio.ScanRunes)
+	}
+	sepBytes := []byte(sep)
+	return st.scan(func(data []byte, atEOF bool) (int, []byte, error) {
+		if i := bytes.Index(data, sepBytes); i >= 0 {
+			return i + len(sepBytes), data[:i], nil
+		}
+		if atEOF {
+			// The final piece is yielded even when empty, as strings.Split does
+			return len(data), data, bufio.ErrFinalToken
+		}
+		return 0, nil, nil
+	})
+}
+
+// Grep returns an iterator over the lines of the input, including their
+// newlines, which contain a match of the regular expression pattern. If
+// pattern does not compile, the iterator yields nothing and the error is
+// reported by [Stream.Err].
+func (st *Stream) Grep(pattern string) iter.Seq[String] {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		st.setErr(err)
+	}
+	lines := st.Lines()
+
+	return func(yield func(String) bool) {
+		if re == nil {
+			return
+		}
+		for line := range lines {
+			if re.MatchString(strings.TrimSuffix(line.Value(), "\n")) && !yield(line) {
+				return
+			}
+		}
+	}
+}
+
+// ReplaceAllTo copies the input to w with all non-overlapping instances of
+// old replaced by new, like [String.ReplaceAll], and returns the number of
+// bytes written. Only a buffer and len(old) bytes are held in memory, and
+// instances of old split across reads of the underlying reader are still
+// replaced. The error is also recorded for [Stream.Err].
+func (st *Stream) ReplaceAllTo(w io.Writer, old, new string) (int64, error) {
+	if st.err != nil {
+		return 0, st.err
+	}
+	bw := bufio.NewWriter(w)
+	cw := &countingWriter{w: bw}
+	var err error
+	if old == "" {
+		err = st.insertAll(cw, new)
+	} else {
+		err = st.replaceAll(cw, old, new)
+	}
+	if err == nil {
+		err = bw.Flush()
+	}
+	st.setErr(err)
+	return cw.n, st.err
+}
+
+// replaceAll implements [Stream.ReplaceAllTo] for a non-empty old
+func (st *Stream) replaceAll(w io.Writer, old, new string) error {
+	oldBytes, newBytes := []byte(old), []byte(new)
+	buf := make([]byte, 0, max(32*1024, 2*len(old)))
+	chunk := make([]byte, 32*1024)
+	for {
+		n, readErr := st.r.Read(chunk)
+		buf = append(buf, chunk[:n]...)
+		atEOF := readErr != nil
+
+		// Replace complete instances, then write all but the bytes which
+		// may start an instance completed by the next read
+		pos := 0
+		for {
+			i := bytes.Index(buf[pos:], oldBytes)
+			if i < 0 {
+				break
+			}
+			if _, err := w.Write(buf[pos : pos+i]); err != nil {
+				return err
+			}
+			if _, err := w.Write(newBytes); err != nil {
+				return err
+			}
+			pos += i + len(oldBytes)
+		}
+		keep := 0
+		if !atEOF {
+			keep = min(len(buf)-pos, len(oldBytes)-1)
+		}
+		if _, err := w.Write(buf[pos : len(buf)-keep]); err != nil {
+			return err
+		}
+		buf = append(buf[:0], buf[len(buf)-keep:]...)
+
+		if atEOF {
+			if readErr == io.EOF {
+				return nil
+			}
+			return readErr
+		}
+	}
+}
+
+// insertAll implements [Stream.ReplaceAllTo] for an empty old, which
+// matches before each UTF-8 sequence and at the end of the input
+func (st *Stream) insertAll(w io.Writer, new string) error {
+	br := bufio.NewReader(st.r)
+	var enc [utf8.UTFMax]byte
+	for {
+		r, size, err := br.ReadRune()
+		if err != nil {
+			if err != io.EOF {
+				return err
+			}
+			_, err = io.WriteString(w, new)
+			return err
+		}
+		if _, err := io.WriteString(w, new); err != nil {
+			return err
+		}
+		// Invalid bytes are copied as they are, not as utf8.RuneError
+		if r == utf8.RuneError && size == 1 {
+			br.UnreadRune()
+			b, _ := br.ReadByte()
+			enc[0] = b
+		} else {
+			utf8.EncodeRune(enc[:], r)
+		}
+		if _, err := w.Write(enc[:size]); err != nil {
+			return err
+		}
+	}
+}
+
+// countingWriter counts the bytes written to w
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+// Write implements [io.Writer]
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
@@ -347,7 +347,7 @@ func (self String) SplitSeq(sep string) iter.Seq[String] {
 	}
 }
 
-// Depreciated: Title
+// Title is deprecated in the standard library, use [String.TitleCase] instead
 
 // ToLower returns the Lowercased version of self
 func (self String) ToLower() String {
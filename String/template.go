@@ -0,0 +1,298 @@
+package String
+
+import (
+	"container/list"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"maps"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"text/template"
+)
+
+// templateName is the name given to templates parsed from Strings, which
+// appears in the errors of the template packages
+const templateName = "String"
+
+// templateFuncs are the functions available in every template, listed in
+// the documentation of [String.Render]
+var templateFuncs = template.FuncMap{
+	"snake":    func(s any) String { return templateText(s).toSnakeCase() },
+	"camel":    func(s any) String { return templateText(s).toCamelCase() },
+	"pad":      func(width int, s any) String { return templateText(s).padRight(width, ' ') },
+	"padLeft":  func(width int, s any) String { return templateText(s).padLeft(width, ' ') },
+	"wrap":     func(width int, s any) String { return templateText(s).wrap(width) },
+	"truncate": func(width int, s any) String { return templateText(s).truncate(width, "…") },
+	"plural":   func(one, many string, n int) String { return plural(n, one, many) },
+	"join":     templateJoin,
+	"upper":    func(s any) String { return templateText(s).ToUpper() },
+	"lower":    func(s any) String { return templateText(s).ToLower() },
+	"title":    func(s any) String { return templateText(s).TitleCase(TitleOptions{}) },
+	"trim":     func(s any) String { return templateText(s).TrimSpace() },
+}
+
+// TemplateFuncs is a set of extra functions for templates rendered by
+// [String.RenderWith], made by [NewTemplateFuncs]. It cannot be changed once
+// made, so templates compiled with it can be cached safely.
+type TemplateFuncs struct {
+	id    uint64
+	funcs template.FuncMap
+}
+
+// lastTemplateFuncs numbers the sets of template functions, so that the
+// templates compiled with each set are cached apart
+var lastTemplateFuncs atomic.Uint64
+
+// NewTemplateFuncs returns a [TemplateFuncs] holding a copy of funcs.
+// Functions with the names of built-in ones replace them.
+func NewTemplateFuncs(funcs template.FuncMap) *TemplateFuncs {
+	return &TemplateFuncs{id: lastTemplateFuncs.Add(1), funcs: maps.Clone(funcs)}
+}
+
+// templateText converts a template argument to a String
+func templateText(v any) String {
+	switch v := v.(type) {
+	case String:
+		return v
+	case string:
+		return New(v)
+	}
+	return New(fmt.Sprint(v))
+}
+
+// templateJoin implements the join template function for slices, arrays
+// and Slices of any element type
+func templateJoin(sep string, items any) (String, error) {
+	v := reflect.ValueOf(items)
+	if v.Kind() == reflect.Struct && v.FieldByName("Items").IsValid() {
+		v = v.FieldByName("Items") // a Slice.Slice
+	}
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return "", fmt.Errorf("join: cannot join %T", items)
+	}
+	texts := make([]string, v.Len())
+	for i := range texts {
+		texts[i] = templateText(v.Index(i).Interface()).Value()
+	}
+	return New(strings.Join(texts, sep)), nil
+}
+
+// TemplateError is returned when a String template fails to parse or
+// execute. It locates the problem in the template text.
+type TemplateError struct {
+	Line    int    // line of the template, starting at 1, or 0 if unknown
+	Column  int    // column in bytes, starting at 1, or 0 if unknown
+	Source  String // the text of the line
+	Message string // the error reported by the template package
+	Err     error
+}
+
+// Error implements the error interface. The message is followed by the
+// template line with a caret under the column, when they are known.
+func (e *TemplateError) Error() string {
+	if e.Line == 0 {
+		return "String template: " + e.Message
+	}
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "String template:%d", e.Line)
+	if e.Column > 0 {
+		fmt.Fprintf(&buf, ":%d", e.Column)
+	}
+	fmt.Fprintf(&buf, ": %s\n\t%s", e.Message, e.Source)
+	if e.Column > 0 {
+		fmt.Fprintf(&buf, "\n\t%s^", strings.Repeat(" ", e.Column-1))
+	}
+	return buf.String()
+}
+
+// Unwrap returns the error of the template package
+func (e *TemplateError) Unwrap() error {
+	return e.Err
+}
+
+// templateErrorPosition matches the position in the errors of the template
+// packages, such as `template: String:3:14: executing "String" at <.X>: ...`
+var templateErrorPosition = regexp.MustCompile(`^(?:html/)?template: ?` + templateName + `:(\d+)(?::(\d+))?: (.*)$`)
+
+// templateError wraps an error of the template packages in a *TemplateError
+func (self String) templateError(err error) error {
+	e := &TemplateError{Message: err.Error(), Err: err}
+	m := templateErrorPosition.FindStringSubmatch(err.Error())
+	if m == nil {
+		return e
+	}
+	e.Line, _ = strconv.Atoi(m[1])
+	if m[2] != "" {
+		// The template packages count columns from 0
+		col, _ := strconv.Atoi(m[2])
+		e.Column = col + 1
+	}
+	e.Message = m[3]
+	if lines := strings.Split(self.Value(), "\n"); e.Line <= len(lines) {
+		e.Source = New(lines[e.Line-1])
+	} else {
+		e.Column = 0
+	}
+	return e
+}
+
+// templateCacheSize is the number of compiled templates kept by
+// [String.Render] and the functions like it
+const templateCacheSize = 256
+
+// templateKey identifies a compiled template in the cache
+type templateKey struct {
+	html  bool
+	funcs uint64 // id of the extra functions, or 0
+	text  String
+}
+
+// compiledTemplate is a template of either template package
+type compiledTemplate interface {
+	Execute(w io.Writer, data any) error
+}
+
+// templateCache keeps the most recently used compiled templates
+type templateCache struct {
+	mu      sync.Mutex
+	order   list.List // of *templateEntry, most recently used first
+	entries map[templateKey]*list.Element
+}
+
+// templateEntry is an element of templateCache.order
+type templateEntry struct {
+	key  templateKey
+	tmpl compiledTemplate
+}
+
+var templates templateCache
+
+// get returns the template cached for key and marks it as recently used
+func (c *templateCache) get(key templateKey) (compiledTemplate, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(e)
+	return e.Value.(*templateEntry).tmpl, true
+}
+
+// put caches tmpl for key, evicting the least recently used template if
+// the cache is full
+func (c *templateCache) put(key templateKey, tmpl compiledTemplate) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[key]; ok {
+		c.order.MoveToFront(e)
+		return
+	}
+	if c.entries == nil {
+		c.entries = map[templateKey]*list.Element{}
+	}
+	if c.order.Len() >= templateCacheSize {
+		oldest := c.order.Back()
+		delete(c.entries, oldest.Value.(*templateEntry).key)
+		c.order.Remove(oldest)
+	}
+	c.entries[key] = c.order.PushFront(&templateEntry{key: key, tmpl: tmpl})
+}
+
+// clear empties the cache
+func (c *templateCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.order.Init()
+	clear(c.entries)
+}
+
+// ClearTemplateCache empties the cache of compiled templates used by
+// [String.Render] and the functions like it
+func ClearTemplateCache() {
+	templates.clear()
+}
+
+// RenderOptions configures [String.RenderWith]. The zero value renders like
+// [String.Render].
+type RenderOptions struct {
+	// HTML uses html/template, as [String.RenderHTML] does
+	HTML bool
+	// Funcs are extra functions available in the template, or nil
+	Funcs *TemplateFuncs
+}
+
+// Render executes self as a text/template with data and returns the
+// output. Besides the builtins of text/template, these functions are
+// available; arguments may be Strings, strings or any other value, which
+// is formatted with fmt.Sprint:
+//
+//	snake s              s in snake_case
+//	camel s              s in camelCase
+//	pad width s          s padded with spaces on the right to width columns
+//	padLeft width s      s padded with spaces on the left to width columns
+//	wrap width s         s word-wrapped to width columns
+//	truncate width s     s cut to width columns, ending in "…" if cut
+//	plural one many n    one if n is 1, otherwise many
+//	join sep items       the items of a slice or Slice joined by sep
+//	upper s, lower s, title s, trim s
+//
+// Widths are terminal columns, as measured by [String.VisibleLength], so
+// styled text and wide characters line up. The value is the last argument,
+// so functions can end a pipeline, as in {{.Name | truncate 20}}.
+//
+// The most recently used compiled templates are cached, so rendering the
+// same String again does not parse it again. Parse and execution errors
+// are returned as a *[TemplateError].
+func (self String) Render(data any) (String, error) {
+	return self.RenderWith(data, RenderOptions{})
+}
+
+// RenderHTML is like [String.Render] but uses html/template, which escapes
+// the values inserted in the output according to their context in the HTML.
+func (self String) RenderHTML(data any) (String, error) {
+	return self.RenderWith(data, RenderOptions{HTML: true})
+}
+
+// RenderWith is like [String.Render] configured by opts
+func (self String) RenderWith(data any, opts RenderOptions) (String, error) {
+	key := templateKey{html: opts.HTML, text: self}
+	if opts.Funcs != nil {
+		key.funcs = opts.Funcs.id
+	}
+	tmpl, ok := templates.get(key)
+	if !ok {
+		var err error
+		if tmpl, err = self.parseTemplate(opts); err != nil {
+			return "", self.templateError(err)
+		}
+		templates.put(key, tmpl)
+	}
+
+	var buf strings.Builder
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", self.templateError(err)
+	}
+	return New(buf.String()), nil
+}
+
+// parseTemplate compiles self with the template package and functions
+// chosen by opts
+func (self String) parseTemplate(opts RenderOptions) (compiledTemplate, error) {
+	var extra template.FuncMap
+	if opts.Funcs != nil {
+		extra = opts.Funcs.funcs
+	}
+	if opts.HTML {
+		return htmltemplate.New(templateName).
+			Funcs(htmltemplate.FuncMap(templateFuncs)).
+			Funcs(htmltemplate.FuncMap(extra)).
+			Parse(self.Value())
+	}
+	return template.New(templateName).Funcs(templateFuncs).Funcs(extra).Parse(self.Value())
+}
@@ -0,0 +1,120 @@
+package String
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/harishtpj/klassy/Slice"
+)
+
+func TestRender(t *testing.T) {
+	data := map[string]any{
+		"Name":  "parseHTTPServer",
+		"Files": 3,
+		"Tags":  Slice.New([]String{"a", "b"}),
+	}
+	tests := []struct {
+		tmpl String
+		want String
+	}{
+		{"{{.Name | snake}}", "parse_http_server"},
+		{"{{.Name | camel}}", "parseHttpServer"},
+		{"[{{pad 6 \"ab\"}}][{{padLeft 6 \"ab\"}}]", "[ab    ][    ab]"},
+		{"{{truncate 8 \"Hello, World\"}}", "Hello, …"},
+		{"{{.Files}} {{plural \"file\" \"files\" .Files}}", "3 files"},
+		{"{{join \", \" .Tags}}", "a, b"},
+		{"{{wrap 5 \"aa bb cc\"}}", "aa bb\ncc"},
+	}
+	for _, tt := range tests {
+		got, err := tt.tmpl.Render(data)
+		if err != nil || got != tt.want {
+			t.Errorf("%q.Render() = %q, %v, want %q", tt.tmpl, got, err, tt.want)
+		}
+	}
+}
+
+func TestRenderHTMLEscapes(t *testing.T) {
+	got, err := New("<p>{{.}}</p>").RenderHTML("<b>")
+	if err != nil || got != "<p>&lt;b&gt;</p>" {
+		t.Errorf("RenderHTML = %q, %v", got, err)
+	}
+}
+
+func TestRenderError(t *testing.T) {
+	_, err := New("line one\n{{.Missing.Field | nosuchfunc}}").Render(nil)
+	var te *TemplateError
+	if !errors.As(err, &te) {
+		t.Fatalf("Render error = %v, want a *TemplateError", err)
+	}
+	if te.Line != 2 || te.Source != "{{.Missing.Field | nosuchfunc}}" {
+		t.Errorf("TemplateError at line %d with source %q", te.Line, te.Source)
+	}
+}
+
+func TestRenderWithFuncs(t *testing.T) {
+	funcs := template.FuncMap{"shout": func(s string) string { return strings.ToUpper(s) + "!" }}
+	extra := NewTemplateFuncs(funcs)
+	funcs["shout"] = func(s string) string { return s }
+
+	tmpl := New("{{shout .}} {{snake \"ParseHTTP\"}}")
+	if got, err := tmpl.RenderWith("hi", RenderOptions{Funcs: extra}); err != nil || got != "HI! parse_http" {
+		t.Errorf("RenderWith = %q, %v", got, err)
+	}
+	if got, err := tmpl.RenderWith("<b>", RenderOptions{HTML: true, Funcs: extra}); err != nil || got != "&lt;B&gt;! parse_http" {
+		t.Errorf("RenderWith HTML = %q, %v", got, err)
+	}
+	// The template cached with the extra functions is not used without them
+	if _, err := tmpl.Render("hi"); err == nil {
+		t.Errorf("Render without the extra functions succeeded")
+	}
+}
+
+func TestTemplateCacheBounded(t *testing.T) {
+	ClearTemplateCache()
+	first := New("first {{.}}")
+	for i := range 2 * templateCacheSize {
+		if i%10 == 0 {
+			first.Render(i) // keep it recently used
+		}
+		if _, err := New(fmt.Sprintf("template %d {{.}}", i)).Render(i); err != nil {
+			t.Fatal(err)
+		}
+	}
+	templates.mu.Lock()
+	n, entries := templates.order.Len(), len(templates.entries)
+	_, kept := templates.entries[templateKey{text: first}]
+	templates.mu.Unlock()
+	if n != templateCacheSize || entries != n {
+		t.Errorf("cache holds %d templates, want %d", n, templateCacheSize)
+	}
+	if !kept {
+		t.Errorf("recently used template was evicted")
+	}
+	ClearTemplateCache()
+	if templates.order.Len() != 0 || len(templates.entries) != 0 {
+		t.Errorf("ClearTemplateCache left templates in the cache")
+	}
+}
+
+func TestPadAndTruncateColumns(t *testing.T) {
+	if got := New("日本").padRight(6, '.'); got != "日本.." {
+		t.Errorf("padRight of wide text = %q", got)
+	}
+	red := New("\x1b[31mHello, World\x1b[0m")
+	got := red.truncate(8, "…")
+	if want := New("\x1b[31mHello, …\x1b[0m"); got != want {
+		t.Errorf("truncate of styled text = %q, want %q", got, want)
+	}
+	if got := New("日本語テキスト").truncate(7, "…"); got != "日本語…" {
+		t.Errorf("truncate of wide text = %q", got)
+	}
+	if got := New("short").truncate(8, "…"); got != "short" {
+		t.Errorf("truncate of short text = %q", got)
+	}
+	if !strings.Contains(New("\x1b[1mab\x1b[0m").truncate(1, "").Value(), "\x1b[0m") {
+		t.Errorf("truncate dropped the reset sequence")
+	}
+}
@@ -0,0 +1,305 @@
+package String
+
+import (
+	"iter"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/harishtpj/klassy/Slice"
+)
+
+// Abbreviations are the words ending in a period which [String.Sentences]
+// does not treat as the end of a sentence. Capital letters followed by a
+// period are only treated as abbreviations when they look like the initials
+// of a name.
+var Abbreviations = []string{
+	"mr", "mrs", "ms", "dr", "prof", "sr", "jr", "st", "mt", "ft",
+	"vs", "etc", "e.g", "i.e", "cf", "approx", "dept",
+	"inc", "ltd", "corp", "jan", "feb",
+	"apr", "jun", "jul", "aug", "sep", "sept", "oct", "nov", "dec",
+	"u.s", "u.k", "a.m", "p.m",
+}
+
+// NumberAbbreviations are abbreviations which are also ordinary words, so
+// [String.Sentences] only treats them as abbreviations when a number
+// follows, as in "No. 5" or "Mar. 3", and "I said no. Then he left." is two
+// sentences.
+var NumberAbbreviations = []string{"no", "nos", "vol", "fig", "est", "mar", "pp"}
+
+// isWordPart reports whether r can appear inside a word. Apostrophes and
+// hyphens are included so that "don't" and "e-mail" count as single words.
+func isWordPart(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r) ||
+		r == '\'' || r == '’' || r == '-'
+}
+
+// WordsSeq returns an iterator over the words of self, as returned by
+// [String.Words], without constructing the slice.
+func (self String) WordsSeq() iter.Seq[String] {
+	fields := self.FieldsFuncSeq(func(r rune) bool { return !isWordPart(r) })
+
+	return func(yield func(String) bool) {
+		for field := range fields {
+			word := field.Trim("'’-")
+			if word == "" {
+				continue
+			}
+			if !yield(word) {
+				return
+			}
+		}
+	}
+}
+
+// Words returns the words of self: runs of letters and digits, which may
+// contain apostrophes and hyphens, with all other characters dropped.
+func (self String) Words() Slice.Slice[String] {
+	words := Slice.New([]String{})
+	words.AppendSeq(self.WordsSeq())
+	return words
+}
+
+// WordCount returns the number of words in self, as returned by [String.Words]
+func (self String) WordCount() int {
+	n := 0
+	for range self.WordsSeq() {
+		n++
+	}
+	return n
+}
+
+// WordFrequencies returns the number of times each word of self occurs.
+// Words are counted case-insensitively and the keys are in lower case.
+func (self String) WordFrequencies() map[String]int {
+	freq := map[String]int{}
+	for word := range self.WordsSeq() {
+		freq[word.ToLower()]++
+	}
+	return freq
+}
+
+// AverageWordLength returns the average number of characters in the words
+// of self, or 0 if self has no words.
+func (self String) AverageWordLength() float64 {
+	words, chars := 0, 0
+	for word := range self.WordsSeq() {
+		words++
+		chars += utf8.RuneCountInString(word.Value())
+	}
+	if words == 0 {
+		return 0
+	}
+	return float64(chars) / float64(words)
+}
+
+// SentencesSeq returns an iterator over the sentences of self, as returned
+// by [String.Sentences], without constructing the slice.
+func (self String) SentencesSeq() iter.Seq[String] {
+	abbrevs := make(map[string]bool, len(Abbreviations))
+	for _, a := range Abbreviations {
+		abbrevs[a] = true
+	}
+	numberAbbrevs := make(map[string]bool, len(NumberAbbreviations))
+	for _, a := range NumberAbbreviations {
+		numberAbbrevs[a] = true
+	}
+
+	return func(yield func(String) bool) {
+		s := self.Value()
+		start := 0
+		for i := 0; i < len(s); {
+			r, size := utf8.DecodeRuneInString(s[i:])
+			i += size
+			if r != '.' && r != '!' && r != '?' && r != '…' {
+				continue
+			}
+			// Include further terminators and closing quotes or brackets
+			end := i
+			for end < len(s) {
+				c, n := utf8.DecodeRuneInString(s[end:])
+				if !strings.ContainsRune(".!?…\"'’”)]", c) {
+					break
+				}
+				end += n
+			}
+			// A sentence only ends before white space or the end of the text
+			next, _ := utf8.DecodeRuneInString(s[end:])
+			if end < len(s) && !unicode.IsSpace(next) {
+				i = end
+				continue
+			}
+			following, _ := utf8.DecodeRuneInString(strings.TrimLeftFunc(s[end:], unicode.IsSpace))
+			if r == '.' && end == i && (isAbbreviation(s[start:i-1], abbrevs) || isInitial(s[start:i-1], s[end:]) ||
+				unicode.IsDigit(following) && isAbbreviation(s[start:i-1], numberAbbrevs)) {
+				continue
+			}
+			// A sentence continuing in lower case, as in "'Why?' she asked.", has not ended
+			if unicode.IsLower(following) {
+				continue
+			}
+			if sentence := strings.TrimSpace(s[start:end]); sentence != "" {
+				if !yield(New(sentence)) {
+					return
+				}
+			}
+			start, i = end, end
+		}
+		if sentence := strings.TrimSpace(s[start:]); sentence != "" {
+			yield(New(sentence))
+		}
+	}
+}
+
+// isAbbreviation reports whether text ends in an abbreviation whose period
+// has been removed
+func isAbbreviation(text string, abbrevs map[string]bool) bool {
+	i := strings.LastIndexFunc(text, func(r rune) bool { return !isWordPart(r) && r != '.' })
+	word := strings.ToLower(text[i+1:])
+	if word == "" {
+		return false
+	}
+	return abbrevs[word]
+}
+
+// isInitial reports whether text ends in a capital letter which, with its
+// period, is the initial of a name rather than the end of a sentence. That is
+// the case when it starts the sentence, follows a capitalized word or another
+// initial, as in "John F. Kennedy", or is followed by another initial, so
+// that "plan B. Then" still ends a sentence.
+func isInitial(text, after string) bool {
+	i := strings.LastIndexFunc(text, func(r rune) bool { return !isWordPart(r) && r != '.' })
+	if r, n := utf8.DecodeRuneInString(text[i+1:]); !unicode.IsUpper(r) || n != len(text[i+1:]) {
+		return false
+	}
+	prev, next := strings.Fields(text[:i+1]), strings.Fields(after)
+	if len(prev) == 0 || len(next) > 0 && isInitialToken(next[0]) {
+		return true
+	}
+	word := strings.TrimLeftFunc(prev[len(prev)-1], func(r rune) bool { return !unicode.IsLetter(r) })
+	r, _ := utf8.DecodeRuneInString(word)
+	return unicode.IsUpper(r)
+}
+
+// isInitialToken reports whether word is a capital letter and a period
+func isInitialToken(word string) bool {
+	r, n := utf8.DecodeRuneInString(word)
+	return unicode.IsUpper(r) && word[n:] == "."
+}
+
+// Sentences splits self into sentences ending in '.', '!', '?' or '…',
+// followed by any closing quotes or brackets. Periods in numbers such as
+// "3.14", in abbreviations listed in [Abbreviations], in those listed in
+// [NumberAbbreviations] before a number and after the initials of names do
+// not end a sentence, and neither does a terminator followed by a word in
+// lower case. Surrounding white space is removed from each sentence.
+func (self String) Sentences() Slice.Slice[String] {
+	sentences := Slice.New([]String{})
+	sentences.AppendSeq(self.SentencesSeq())
+	return sentences
+}
+
+// TextStats holds counts describing a text, used to compute readability scores
+type TextStats struct {
+	Characters   int // letters and digits in words
+	Words        int
+	Sentences    int
+	Syllables    int // estimated English syllables
+	ComplexWords int // words with three or more syllables
+}
+
+// Stats computes the [TextStats] of self in a single pass over its sentences
+// and words.
+func (self String) Stats() TextStats {
+	var stats TextStats
+	for sentence := range self.SentencesSeq() {
+		stats.Sentences++
+		for word := range sentence.WordsSeq() {
+			stats.Words++
+			for _, r := range word.Value() {
+				if unicode.IsLetter(r) || unicode.IsDigit(r) {
+					stats.Characters++
+				}
+			}
+			syllables := countSyllables(word.Value())
+			stats.Syllables += syllables
+			if syllables >= 3 {
+				stats.ComplexWords++
+			}
+		}
+	}
+	return stats
+}
+
+// countSyllables estimates the number of syllables of an English word by
+// counting groups of vowels, ignoring a silent final 'e'
+func countSyllables(word string) int {
+	word = strings.ToLower(word)
+	count, prevVowel := 0, false
+	for _, r := range word {
+		vowel := strings.ContainsRune("aeiouy", r)
+		if vowel && !prevVowel {
+			count++
+		}
+		prevVowel = vowel
+	}
+	if strings.HasSuffix(word, "e") && !strings.HasSuffix(word, "le") && count > 1 {
+		count--
+	}
+	return max(count, 1)
+}
+
+// ratios returns the average sentence length and word length used by the scores
+func (t TextStats) ratios() (wordsPerSentence, syllablesPerWord float64) {
+	if t.Words == 0 || t.Sentences == 0 {
+		return 0, 0
+	}
+	return float64(t.Words) / float64(t.Sentences), float64(t.Syllables) / float64(t.Words)
+}
+
+// FleschReadingEase returns the Flesch reading-ease score, where higher
+// scores mean easier text; 60 to 70 is plain English.
+func (t TextStats) FleschReadingEase() float64 {
+	if t.Words == 0 {
+		return 0
+	}
+	wps, spw := t.ratios()
+	return 206.835 - 1.015*wps - 84.6*spw
+}
+
+// FleschKincaidGrade returns the Flesch-Kincaid grade level, the number of
+// years of U.S. schooling needed to understand the text.
+func (t TextStats) FleschKincaidGrade() float64 {
+	if t.Words == 0 {
+		return 0
+	}
+	wps, spw := t.ratios()
+	return 0.39*wps + 11.8*spw - 15.59
+}
+
+// GunningFog returns the Gunning fog index, the years of formal education
+// needed to understand the text on a first reading.
+func (t TextStats) GunningFog() float64 {
+	if t.Words == 0 {
+		return 0
+	}
+	wps, _ := t.ratios()
+	return 0.4 * (wps + 100*float64(t.ComplexWords)/float64(t.Words))
+}
+
+// FleschKincaidGrade returns the Flesch-Kincaid grade level of self, see
+// [TextStats.FleschKincaidGrade].
+func (self String) FleschKincaidGrade() float64 {
+	return self.Stats().FleschKincaidGrade()
+}
+
+// FleschReadingEase returns the Flesch reading-ease score of self, see
+// [TextStats.FleschReadingEase].
+func (self String) FleschReadingEase() float64 {
+	return self.Stats().FleschReadingEase()
+}
+
+// GunningFog returns the Gunning fog index of self, see [TextStats.GunningFog].
+func (self String) GunningFog() float64 {
+	return self.Stats().GunningFog()
+}
@@ -0,0 +1,40 @@
+package String
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSentences(t *testing.T) {
+	tests := []struct {
+		text String
+		want []String
+	}{
+		{"I said no. Then he left.", []String{"I said no.", "Then he left."}},
+		{"See item No. 5 here. Done.", []String{"See item No. 5 here.", "Done."}},
+		{"Prices rose in Mar. 2024 sharply.", []String{"Prices rose in Mar. 2024 sharply."}},
+		{"Don't mar. It is fine.", []String{"Don't mar.", "It is fine."}},
+		{"Acme was est. 1990 in Ohio.", []String{"Acme was est. 1990 in Ohio."}},
+		{"Mr. Smith arrived. He sat.", []String{"Mr. Smith arrived.", "He sat."}},
+		{"J. R. R. Tolkien wrote it. Yes!", []String{"J. R. R. Tolkien wrote it.", "Yes!"}},
+		{"John F. Kennedy spoke.", []String{"John F. Kennedy spoke."}},
+		{"The answer is A. The next one is B. Done.", []String{"The answer is A.", "The next one is B.", "Done."}},
+		{"We need plan B. Then go.", []String{"We need plan B.", "Then go."}},
+		{"Take vitamin C. It helps.", []String{"Take vitamin C.", "It helps."}},
+		{"Pi is 3.14. Really?", []String{"Pi is 3.14.", "Really?"}},
+		{"'Why?' she asked. Nobody knew.", []String{"'Why?' she asked.", "Nobody knew."}},
+	}
+	for _, tt := range tests {
+		if got := tt.text.Sentences().Items; !slices.Equal(got, tt.want) {
+			t.Errorf("%q.Sentences() = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestWords(t *testing.T) {
+	got := New("Don't stop -- e-mail me, 'now'!").Words().Items
+	want := []String{"Don't", "stop", "e-mail", "me", "now"}
+	if !slices.Equal(got, want) {
+		t.Errorf("Words() = %q, want %q", got, want)
+	}
+}
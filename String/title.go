@@ -0,0 +1,102 @@
+package String
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// DefaultSmallWords are the English articles, conjunctions and short
+// prepositions which [String.TitleCase] keeps in lower case by default
+var DefaultSmallWords = []string{
+	"a", "an", "and", "as", "at", "but", "by", "en", "for", "if", "in",
+	"nor", "of", "on", "or", "per", "so", "the", "to", "up", "v", "via",
+	"vs", "yet",
+}
+
+// TitleOptions controls how [String.TitleCase] capitalizes words
+type TitleOptions struct {
+	// SmallWords are kept in lower case unless they start or end the title
+	// or a subtitle. If nil, DefaultSmallWords is used; an empty non-nil
+	// slice capitalizes every word.
+	SmallWords []string
+	// Special provides language-specific case mappings, such as
+	// unicode.TurkishCase for the dotted and dotless i
+	Special unicode.SpecialCase
+}
+
+// TitleCase returns a copy of self with its words capitalized as in a title.
+// The first letter of each word is mapped to title case and the rest of the
+// word is left alone, so "don't" becomes "Don't". Words containing capital
+// letters after their first letter, such as acronyms ("NASA") and brand
+// names ("iPhone"), are left unchanged. The small words in opts are written
+// in lower case except at the start or end of the title and after a colon
+// or a sentence end. Each part of a hyphenated word is treated as a word,
+// so "up-to-date" becomes "Up-to-Date".
+//
+// TitleCase replaces the deprecated Title function of the strings package,
+// which does not handle Unicode punctuation properly.
+func (self String) TitleCase(opts TitleOptions) String {
+	smallWords := opts.SmallWords
+	if smallWords == nil {
+		smallWords = DefaultSmallWords
+	}
+	small := make(map[string]bool, len(smallWords))
+	for _, w := range smallWords {
+		small[strings.ToLowerSpecial(opts.Special, w)] = true
+	}
+
+	words := strings.Fields(self.Value())
+	var buf strings.Builder
+	buf.Grow(self.Length())
+	rest := self.Value()
+	startsPhrase := true
+	for i, word := range words {
+		// Copy the white space before the word unchanged
+		at := strings.Index(rest, word)
+		buf.WriteString(rest[:at])
+		rest = rest[at+len(word):]
+
+		parts := strings.Split(word, "-")
+		for j, part := range parts {
+			if j > 0 {
+				buf.WriteByte('-')
+			}
+			// The head of a hyphenated compound is always capitalized
+			first := j == 0 && (startsPhrase || len(parts) > 1)
+			last := i == len(words)-1 && j == len(parts)-1
+			buf.WriteString(titleWord(part, small, first || last, opts.Special))
+		}
+		startsPhrase = strings.ContainsAny(word[len(word)-1:], ":.!?")
+	}
+	buf.WriteString(rest)
+	return New(buf.String())
+}
+
+// titleWord capitalizes a single word, which may be surrounded by punctuation
+func titleWord(word string, small map[string]bool, force bool, special unicode.SpecialCase) string {
+	start := strings.IndexFunc(word, isWordRune)
+	if start < 0 {
+		return word
+	}
+	end := strings.LastIndexFunc(word, isWordRune)
+	_, size := utf8.DecodeRuneInString(word[end:])
+	core := word[start : end+size]
+
+	lower := strings.ToLowerSpecial(special, core)
+	if small[lower] && !force {
+		return word[:start] + lower + word[end+size:]
+	}
+
+	r, n := utf8.DecodeRuneInString(core)
+	if strings.IndexFunc(core[n:], unicode.IsUpper) >= 0 {
+		// Acronyms and mixed-case names keep their casing
+		return word
+	}
+	return word[:start] + string(special.ToTitle(r)) + word[start+n:]
+}
+
+// isWordRune reports whether r can be part of a word
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
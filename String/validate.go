@@ -0,0 +1,514 @@
+package String
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/mail"
+	"net/netip"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ValidationError describes why a String failed a validation rule
+type ValidationError struct {
+	Rule   string // name of the failed rule, such as "email"
+	Value  string // the validated text
+	Reason string
+}
+
+// Error implements the error interface
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%q is not a valid %s: %s", e.Value, e.Rule, e.Reason)
+}
+
+// invalid returns a *ValidationError for self
+func (self String) invalid(rule, format string, args ...any) error {
+	return &ValidationError{Rule: rule, Value: self.Value(), Reason: fmt.Sprintf(format, args...)}
+}
+
+// Validate checks self against every rule and returns the errors of the
+// failed ones joined together, or nil if self passes them all. The Validate
+// methods of String can be used as rules directly, for example
+//
+//	s.Validate(String.ValidateASCII, String.ValidateEmail)
+func (self String) Validate(rules ...func(String) error) error {
+	var errs []error
+	for _, rule := range rules {
+		if err := rule(self); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ValidateEmail returns an error unless self is a bare e-mail address such
+// as "user@example.com", without a display name or angle brackets, whose
+// domain is a host name with at least two labels.
+func (self String) ValidateEmail() error {
+	addr, err := mail.ParseAddress(self.Value())
+	if err != nil {
+		return self.invalid("email", "%v", strings.TrimPrefix(err.Error(), "mail: "))
+	}
+	if addr.Name != "" || addr.Address != self.Value() {
+		return self.invalid("email", "expected a bare address")
+	}
+	_, domain, _ := strings.Cut(addr.Address, "@")
+	if !strings.Contains(domain, ".") || New(domain).ValidateHostname() != nil {
+		return self.invalid("email", "invalid domain %q", domain)
+	}
+	return nil
+}
+
+// IsEmail reports whether self is a bare e-mail address, see [String.ValidateEmail]
+func (self String) IsEmail() bool {
+	return self.ValidateEmail() == nil
+}
+
+// URLOptions controls which URLs [String.IsURL] accepts
+type URLOptions struct {
+	// Schemes lists the accepted schemes in lower case. If empty, any
+	// scheme is accepted.
+	Schemes []string
+	// AllowRelative accepts URLs without a scheme and host, such as "/a/b"
+	AllowRelative bool
+}
+
+// ValidateURL returns an error unless self is a URL accepted by opts. By
+// default the URL must be absolute, with a scheme and a host.
+func (self String) ValidateURL(opts URLOptions) error {
+	u, err := url.Parse(self.Value())
+	if err != nil {
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return self.invalid("URL", "%v", err)
+	}
+	if self.Value() == "" || self.ContainsFunc(unicode.IsSpace) {
+		return self.invalid("URL", "empty or contains white space")
+	}
+	if !opts.AllowRelative {
+		if u.Scheme == "" {
+			return self.invalid("URL", "missing scheme")
+		}
+		if u.Host == "" {
+			return self.invalid("URL", "missing host")
+		}
+	}
+	if u.Scheme != "" && len(opts.Schemes) > 0 && !slices.Contains(opts.Schemes, strings.ToLower(u.Scheme)) {
+		return self.invalid("URL", "scheme %q not allowed", u.Scheme)
+	}
+	return nil
+}
+
+// IsURL reports whether self is a URL accepted by opts, see [String.ValidateURL]
+func (self String) IsURL(opts URLOptions) bool {
+	return self.ValidateURL(opts) == nil
+}
+
+// ValidateIPv4 returns an error unless self is an IPv4 address in dotted
+// decimal form, such as "192.0.2.1".
+func (self String) ValidateIPv4() error {
+	addr, err := netip.ParseAddr(self.Value())
+	if err != nil {
+		return self.invalid("IPv4 address", "%s", netipReason(err))
+	}
+	if !addr.Is4() {
+		return self.invalid("IPv4 address", "is an IPv6 address")
+	}
+	return nil
+}
+
+// IsIPv4 reports whether self is an IPv4 address, see [String.ValidateIPv4]
+func (self String) IsIPv4() bool {
+	return self.ValidateIPv4() == nil
+}
+
+// ValidateIPv6 returns an error unless self is an IPv6 address, such as
+// "2001:db8::1", optionally with a zone.
+func (self String) ValidateIPv6() error {
+	addr, err := netip.ParseAddr(self.Value())
+	if err != nil {
+		return self.invalid("IPv6 address", "%s", netipReason(err))
+	}
+	if !addr.Is6() {
+		return self.invalid("IPv6 address", "is an IPv4 address")
+	}
+	return nil
+}
+
+// IsIPv6 reports whether self is an IPv6 address, see [String.ValidateIPv6]
+func (self String) IsIPv6() bool {
+	return self.ValidateIPv6() == nil
+}
+
+// netipReason strips the function name and input that errors of the
+// netip package start with
+func netipReason(err error) string {
+	if _, reason, found := strings.Cut(err.Error(), "): "); found {
+		return reason
+	}
+	return err.Error()
+}
+
+// ValidateCIDR returns an error unless self is an IP network in CIDR
+// notation, such as "192.0.2.0/24" or "2001:db8::/32".
+func (self String) ValidateCIDR() error {
+	if _, err := netip.ParsePrefix(self.Value()); err != nil {
+		return self.invalid("CIDR prefix", "%s", netipReason(err))
+	}
+	return nil
+}
+
+// IsCIDR reports whether self is an IP network in CIDR notation, see [String.ValidateCIDR]
+func (self String) IsCIDR() bool {
+	return self.ValidateCIDR() == nil
+}
+
+// ValidateHostname returns an error unless self is a host name following
+// RFC 1123: dot-separated labels of at most 63 letters, digits and hyphens
+// which neither start nor end with a hyphen, 253 characters at most in
+// total. A single trailing dot is allowed.
+func (self String) ValidateHostname() error {
+	host := strings.TrimSuffix(self.Value(), ".")
+	if host == "" || len(host) > 253 {
+		return self.invalid("hostname", "length must be between 1 and 253")
+	}
+	for label := range strings.SplitSeq(host, ".") {
+		if label == "" || len(label) > 63 {
+			return self.invalid("hostname", "label length must be between 1 and 63")
+		}
+		if label[0] == '-' || label[len(label)-1] == '-' {
+			return self.invalid("hostname", "label %q starts or ends with a hyphen", label)
+		}
+		for _, r := range label {
+			if !(r < utf8.RuneSelf && (isAlnumASCII(byte(r)) || r == '-')) {
+				return self.invalid("hostname", "invalid character %q", r)
+			}
+		}
+	}
+	return nil
+}
+
+// IsHostname reports whether self is a valid host name, see [String.ValidateHostname]
+func (self String) IsHostname() bool {
+	return self.ValidateHostname() == nil
+}
+
+// isAlnumASCII reports whether c is an ASCII letter or digit
+func isAlnumASCII(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9'
+}
+
+// isHexDigit reports whether c is an ASCII hexadecimal digit
+func isHexDigit(c byte) bool {
+	return '0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F'
+}
+
+// ValidateUUID returns an error unless self is a UUID in its canonical
+// 8-4-4-4-12 hexadecimal form. If version is between 1 and 8, the UUID must
+// have that version and the RFC 9562 variant; if version is 0, any UUID
+// is accepted.
+func (self String) ValidateUUID(version int) error {
+	s := self.Value()
+	if len(s) != 36 {
+		return self.invalid("UUID", "length must be 36")
+	}
+	for i := 0; i < len(s); i++ {
+		switch i {
+		case 8, 13, 18, 23:
+			if s[i] != '-' {
+				return self.invalid("UUID", "expected '-' at offset %d", i)
+			}
+		default:
+			if !isHexDigit(s[i]) {
+				return self.invalid("UUID", "invalid hexadecimal digit %q", s[i])
+			}
+		}
+	}
+	if version == 0 {
+		return nil
+	}
+	if got := int(s[14] - '0'); got != version {
+		return self.invalid("UUID", "version %c, expected %d", s[14], version)
+	}
+	if !strings.ContainsRune("89abAB", rune(s[19])) {
+		return self.invalid("UUID", "unknown variant")
+	}
+	return nil
+}
+
+// IsUUID reports whether self is a UUID of the given version, or any
+// version if it is 0, see [String.ValidateUUID]
+func (self String) IsUUID(version int) bool {
+	return self.ValidateUUID(version) == nil
+}
+
+// semverPattern is the regular expression suggested by semver.org
+var semverPattern = regexp.MustCompile(`^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)` +
+	`(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?` +
+	`(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
+
+// ValidateSemver returns an error unless self is a version following
+// Semantic Versioning 2.0.0, such as "1.2.3-rc.1+build.5", without a
+// leading "v".
+func (self String) ValidateSemver() error {
+	if !semverPattern.MatchString(self.Value()) {
+		return self.invalid("semantic version", "expected MAJOR.MINOR.PATCH[-PRERELEASE][+BUILD]")
+	}
+	return nil
+}
+
+// IsSemver reports whether self is a semantic version, see [String.ValidateSemver]
+func (self String) IsSemver() bool {
+	return self.ValidateSemver() == nil
+}
+
+// iso8601Layouts are the ISO 8601 forms accepted by ValidateISO8601
+var iso8601Layouts = []string{
+	"2006-01-02",
+	"2006-01",
+	"20060102",
+	"2006-01-02T15:04Z07:00",
+	"2006-01-02T15:04",
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"20060102T150405Z0700",
+	"20060102T150405",
+}
+
+// ValidateISO8601 returns an error unless self is a calendar date or a date
+// and time in one of the common ISO 8601 forms, such as "2024-03-01",
+// "2024-03-01T12:30:00Z" or "20240301T123000+0100".
+func (self String) ValidateISO8601() error {
+	s := strings.Replace(self.Value(), "z", "Z", 1)
+	for _, layout := range iso8601Layouts {
+		if _, err := time.Parse(layout, s); err == nil {
+			return nil
+		}
+	}
+	return self.invalid("ISO 8601 date", "unrecognised date or time format")
+}
+
+// IsISO8601 reports whether self is an ISO 8601 date or time, see [String.ValidateISO8601]
+func (self String) IsISO8601() bool {
+	return self.ValidateISO8601() == nil
+}
+
+// luhnValid reports whether the digits of s pass the Luhn checksum
+func luhnValid(digits string) bool {
+	sum := 0
+	double := false
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+// ValidateCreditCard returns an error unless self is a payment card number
+// of 12 to 19 digits passing the Luhn checksum. Spaces and hyphens between
+// digit groups are ignored.
+func (self String) ValidateCreditCard() error {
+	digits := strings.NewReplacer(" ", "", "-", "").Replace(self.Value())
+	if !isNumeric(digits) {
+		return self.invalid("card number", "must contain only digits")
+	}
+	if len(digits) < 12 || len(digits) > 19 {
+		return self.invalid("card number", "must have 12 to 19 digits")
+	}
+	if !luhnValid(digits) {
+		return self.invalid("card number", "checksum mismatch")
+	}
+	return nil
+}
+
+// IsCreditCard reports whether self is a payment card number, see [String.ValidateCreditCard]
+func (self String) IsCreditCard() bool {
+	return self.ValidateCreditCard() == nil
+}
+
+// ibanLengths holds the IBAN length of each country using IBANs
+var ibanLengths = map[string]int{
+	"AD": 24, "AE": 23, "AL": 28, "AT": 20, "AZ": 28, "BA": 20, "BE": 16,
+	"BG": 22, "BH": 22, "BR": 29, "BY": 28, "CH": 21, "CR": 22, "CY": 28,
+	"CZ": 24, "DE": 22, "DK": 18, "DO": 28, "EE": 20, "EG": 29, "ES": 24,
+	"FI": 18, "FO": 18, "FR": 27, "GB": 22, "GE": 22, "GI": 23, "GL": 18,
+	"GR": 27, "GT": 28, "HR": 21, "HU": 28, "IE": 22, "IL": 23, "IQ": 23,
+	"IS": 26, "IT": 27, "JO": 30, "KW": 30, "KZ": 20, "LB": 28, "LC": 32,
+	"LI": 21, "LT": 20, "LU": 20, "LV": 21, "MC": 27, "MD": 24, "ME": 22,
+	"MK": 19, "MR": 27, "MT": 31, "MU": 30, "NL": 18, "NO": 15, "PK": 24,
+	"PL": 28, "PS": 29, "PT": 25, "QA": 29, "RO": 24, "RS": 22, "SA": 24,
+	"SC": 31, "SE": 24, "SI": 19, "SK": 24, "SM": 27, "ST": 25, "SV": 28,
+	"TL": 23, "TN": 24, "TR": 26, "UA": 29, "VA": 22, "VG": 24, "XK": 20,
+}
+
+// ValidateIBAN returns an error unless self is an International Bank
+// Account Number with the length used by its country and a valid ISO 7064
+// mod 97 checksum. Spaces are ignored and letters may be in any case.
+func (self String) ValidateIBAN() error {
+	iban := strings.ToUpper(strings.ReplaceAll(self.Value(), " ", ""))
+	if len(iban) < 15 {
+		return self.invalid("IBAN", "too short")
+	}
+	for i := 0; i < len(iban); i++ {
+		if !isAlnumASCII(iban[i]) {
+			return self.invalid("IBAN", "invalid character %q", iban[i])
+		}
+	}
+	want, ok := ibanLengths[iban[:2]]
+	if !ok {
+		return self.invalid("IBAN", "unknown country code %q", iban[:2])
+	}
+	if len(iban) != want {
+		return self.invalid("IBAN", "length %d, expected %d for %s", len(iban), want, iban[:2])
+	}
+
+	// Move the country code and check digits to the end and turn letters
+	// into numbers, A = 10 to Z = 35
+	var digits strings.Builder
+	for _, c := range iban[4:] + iban[:4] {
+		if c >= 'A' {
+			fmt.Fprint(&digits, int(c-'A')+10)
+		} else {
+			digits.WriteRune(c)
+		}
+	}
+	n, _ := new(big.Int).SetString(digits.String(), 10)
+	if n.Mod(n, big.NewInt(97)).Int64() != 1 {
+		return self.invalid("IBAN", "checksum mismatch")
+	}
+	return nil
+}
+
+// IsIBAN reports whether self is a valid IBAN, see [String.ValidateIBAN]
+func (self String) IsIBAN() bool {
+	return self.ValidateIBAN() == nil
+}
+
+// ValidateJSON returns an error unless self is a valid JSON document.
+func (self String) ValidateJSON() error {
+	var v any
+	if err := json.Unmarshal([]byte(self.Value()), &v); err != nil {
+		return self.invalid("JSON document", "%v", err)
+	}
+	return nil
+}
+
+// IsJSON reports whether self is valid JSON
+func (self String) IsJSON() bool {
+	return json.Valid([]byte(self.Value()))
+}
+
+// ValidateBase64 returns an error unless self is non-empty, padded standard
+// base64 as defined in RFC 4648.
+func (self String) ValidateBase64() error {
+	if self == "" {
+		return self.invalid("base64 text", "empty")
+	}
+	if _, err := base64.StdEncoding.Strict().DecodeString(self.Value()); err != nil {
+		return self.invalid("base64 text", "%v", err)
+	}
+	return nil
+}
+
+// IsBase64 reports whether self is standard base64, see [String.ValidateBase64]
+func (self String) IsBase64() bool {
+	return self.ValidateBase64() == nil
+}
+
+// ValidateHexColor returns an error unless self is a CSS hexadecimal colour
+// of the form "#rgb", "#rgba", "#rrggbb" or "#rrggbbaa".
+func (self String) ValidateHexColor() error {
+	hex, found := self.CutPrefix("#")
+	if !found {
+		return self.invalid("hex colour", "must start with '#'")
+	}
+	switch hex.Length() {
+	case 3, 4, 6, 8:
+	default:
+		return self.invalid("hex colour", "must have 3, 4, 6 or 8 digits")
+	}
+	for i := 0; i < hex.Length(); i++ {
+		if !isHexDigit(hex[i]) {
+			return self.invalid("hex colour", "invalid hexadecimal digit %q", hex[i])
+		}
+	}
+	return nil
+}
+
+// IsHexColor reports whether self is a hexadecimal colour, see [String.ValidateHexColor]
+func (self String) IsHexColor() bool {
+	return self.ValidateHexColor() == nil
+}
+
+// numericPattern matches decimal numbers with an optional sign, fraction
+// and exponent
+var numericPattern = regexp.MustCompile(`^[+-]?(\d+\.?\d*|\.\d+)([eE][+-]?\d+)?$`)
+
+// ValidateNumeric returns an error unless self is a decimal number with an
+// optional sign, fractional part and exponent, such as "-12", "3.5" or "1e9".
+func (self String) ValidateNumeric() error {
+	if !numericPattern.MatchString(self.Value()) {
+		return self.invalid("number", "expected decimal digits")
+	}
+	return nil
+}
+
+// IsNumeric reports whether self is a decimal number, see [String.ValidateNumeric]
+func (self String) IsNumeric() bool {
+	return self.ValidateNumeric() == nil
+}
+
+// ValidateASCII returns an error unless every character of self is ASCII.
+func (self String) ValidateASCII() error {
+	for i := 0; i < self.Length(); i++ {
+		if self[i] >= utf8.RuneSelf {
+			return self.invalid("ASCII text", "non-ASCII byte at offset %d", i)
+		}
+	}
+	return nil
+}
+
+// IsASCII reports whether every character of self is ASCII
+func (self String) IsASCII() bool {
+	return self.ValidateASCII() == nil
+}
+
+// ValidatePrintable returns an error unless self is valid UTF-8 whose
+// characters are all printable as defined by [unicode.IsPrint], which
+// excludes control characters such as tabs and newlines.
+func (self String) ValidatePrintable() error {
+	for i, r := range self.Value() {
+		if r == utf8.RuneError {
+			if _, size := utf8.DecodeRuneInString(self.Value()[i:]); size == 1 {
+				return self.invalid("printable text", "invalid UTF-8 at offset %d", i)
+			}
+		}
+		if !unicode.IsPrint(r) {
+			return self.invalid("printable text", "unprintable character %q at offset %d", r, i)
+		}
+	}
+	return nil
+}
+
+// IsPrintable reports whether self only contains printable characters, see
+// [String.ValidatePrintable]
+func (self String) IsPrintable() bool {
+	return self.ValidatePrintable() == nil
+}